@@ -0,0 +1,23 @@
+ALTER TABLE users ADD COLUMN leverage integer NOT NULL DEFAULT 1;
+ALTER TABLE positions ADD COLUMN margin numeric NOT NULL DEFAULT 0;
+
+-- Positions which are open have been paid in full, so they keep their price as margin like with leverage 1
+UPDATE positions SET margin = price_open * count WHERE price_close IS NULL;
+
+-- The balance doesn't pay for positions anymore, they only reserve margin and book pnl when they close.
+-- The payments for the open positions are returned to the balances
+CREATE TEMPORARY TABLE refund AS
+SELECT id AS position_id, user_id, CASE WHEN is_buy THEN price_open * count ELSE -price_open * count END AS amount,
+    nextval('ledger_transactions_sequence') AS transaction_id
+FROM positions WHERE price_close IS NULL;
+
+INSERT INTO ledger_entries (id, transaction_id, user_id, account, entry_type, amount, position_id, order_id, time_create)
+SELECT nextval('ledger_entries_sequence'), transaction_id, user_id, a.account, 'position_open', a.amount, position_id,
+    NULL, CURRENT_TIMESTAMP
+FROM refund, LATERAL (VALUES ('user', amount), ('trading', -amount)) AS a(account, amount);
+
+UPDATE users SET balance = COALESCE(balance, 0) + r.amount
+FROM (SELECT user_id, sum(amount) AS amount FROM refund GROUP BY user_id) AS r
+WHERE users.id = r.user_id;
+
+DROP TABLE refund;
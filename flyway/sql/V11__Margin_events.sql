@@ -0,0 +1,14 @@
+CREATE TABLE margin_events (
+    id SERIAL PRIMARY KEY,
+    user_id integer REFERENCES users(id) NOT NULL,
+    event_type varchar(20) NOT NULL,
+    position_id integer REFERENCES positions(id),
+    equity numeric NOT NULL,
+    used_margin numeric NOT NULL,
+    margin_level numeric NOT NULL,
+    time_create timestamp NOT NULL
+);
+
+CREATE INDEX margin_events_user_id_idx ON margin_events (user_id, time_create);
+
+CREATE SEQUENCE margin_events_sequence;
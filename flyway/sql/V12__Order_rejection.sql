@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN time_reject timestamp;
+ALTER TABLE orders ADD COLUMN reject_reason varchar(255);
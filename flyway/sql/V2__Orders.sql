@@ -0,0 +1,15 @@
+CREATE TABLE orders (
+    id SERIAL PRIMARY KEY,
+    user_id integer REFERENCES users(id) NOT NULL,
+    symbol_id integer NOT NULL,
+    count integer NOT NULL,
+    price numeric NOT NULL,
+    stop_loss numeric,
+    take_profit numeric,
+    is_buy boolean,
+    time_create timestamp,
+    time_fill timestamp,
+    position_id integer REFERENCES positions(id)
+);
+
+CREATE SEQUENCE orders_sequence;
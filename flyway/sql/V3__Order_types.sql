@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN order_type integer NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE positions ADD COLUMN trailing_stop numeric NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE positions ADD COLUMN parent_id integer REFERENCES positions(id);
+ALTER TABLE positions ADD COLUMN pnl numeric;
@@ -0,0 +1,5 @@
+ALTER TABLE positions ADD COLUMN close_reason varchar(20);
+
+UPDATE positions SET pnl = CASE WHEN is_buy THEN (price_close - price_open) * count
+    ELSE (price_open - price_close) * count END
+WHERE price_close IS NOT NULL AND pnl IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN login varchar(40) UNIQUE;
+ALTER TABLE users ADD COLUMN password_hash varchar(60);
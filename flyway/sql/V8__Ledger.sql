@@ -0,0 +1,28 @@
+CREATE TABLE ledger_entries (
+    id SERIAL PRIMARY KEY,
+    transaction_id integer NOT NULL,
+    user_id integer REFERENCES users(id) NOT NULL,
+    account varchar(20) NOT NULL,
+    entry_type varchar(20) NOT NULL,
+    amount numeric NOT NULL,
+    position_id integer REFERENCES positions(id),
+    order_id integer REFERENCES orders(id),
+    time_create timestamp NOT NULL
+);
+
+CREATE INDEX ledger_entries_user_id_idx ON ledger_entries (user_id, time_create);
+
+CREATE SEQUENCE ledger_entries_sequence;
+CREATE SEQUENCE ledger_transactions_sequence;
+
+-- Balances before the ledger are booked as opening adjustments, so the ledger sums to the current balances
+CREATE TEMPORARY TABLE opening AS
+SELECT id AS user_id, balance, nextval('ledger_transactions_sequence') AS transaction_id
+FROM users WHERE balance IS NOT NULL AND balance <> 0;
+
+INSERT INTO ledger_entries (id, transaction_id, user_id, account, entry_type, amount, position_id, order_id, time_create)
+SELECT nextval('ledger_entries_sequence'), transaction_id, user_id, a.account, 'adjustment', a.amount, NULL, NULL,
+    CURRENT_TIMESTAMP
+FROM opening, LATERAL (VALUES ('user', balance), ('adjustments', -balance)) AS a(account, amount);
+
+DROP TABLE opening;
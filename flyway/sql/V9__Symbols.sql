@@ -0,0 +1,19 @@
+CREATE TABLE symbols (
+    id SERIAL PRIMARY KEY,
+    ticker varchar(20) UNIQUE NOT NULL,
+    title varchar(40) NOT NULL,
+    tradable boolean NOT NULL DEFAULT true,
+    lot_size integer NOT NULL DEFAULT 1,
+    tick_size numeric NOT NULL DEFAULT 0.000001,
+    currency varchar(3) NOT NULL DEFAULT 'USD'
+);
+
+-- The symbols which were hard-coded before, their ids are ids of prices in the pricer
+INSERT INTO symbols (id, ticker, title) VALUES
+    (1, 'SYM1', 'Symbol 1'),
+    (2, 'SYM2', 'Symbol 2'),
+    (3, 'SYM3', 'Symbol 3'),
+    (4, 'SYM4', 'Symbol 4'),
+    (5, 'SYM5', 'Symbol 5');
+
+CREATE SEQUENCE symbols_sequence START 6;
@@ -0,0 +1,111 @@
+// Package auth hashes passwords and issues signed session tokens
+package auth
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken means that the token is malformed or its signature is wrong
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken means that the token was valid but its lifetime is over
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+// claims is a payload of the token
+type claims struct {
+	UserID  int32 `json:"user_id"`
+	Expires int64 `json:"exp"`
+}
+
+// Manager issues and checks tokens
+type Manager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewManager is constructor
+func NewManager(secret string, ttl time.Duration) *Manager {
+	return &Manager{secret: []byte(secret), ttl: ttl}
+}
+
+// Generate returns a token of the user. The token is "payload.signature" where both parts are base64 encoded
+// and signature is HMAC-SHA256 of the payload
+func (m *Manager) Generate(userID int32) (string, error) {
+	payload, err := json.Marshal(claims{
+		UserID:  userID,
+		Expires: time.Now().Add(m.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(m.sign(encoded)), nil
+}
+
+// Parse checks the token and returns id of its user
+func (m *Manager) Parse(token string) (int32, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, m.sign(parts[0])) {
+		return 0, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	var c claims
+	if err = json.Unmarshal(payload, &c); err != nil {
+		return 0, ErrInvalidToken
+	}
+	if time.Now().Unix() >= c.Expires {
+		return 0, ErrExpiredToken
+	}
+	return c.UserID, nil
+}
+
+func (m *Manager) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// HashPassword returns bcrypt hash of the password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword returns true if the password matches the hash
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// contextKey is a key of user's id in the context
+type contextKey struct{}
+
+// WithUserID returns a copy of ctx which carries id of the authenticated user
+func WithUserID(ctx context.Context, userID int32) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserIDFromContext returns id of the authenticated user
+func UserIDFromContext(ctx context.Context) (int32, bool) {
+	userID, ok := ctx.Value(contextKey{}).(int32)
+	return userID, ok
+}
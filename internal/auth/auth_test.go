@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestManager_Parse(t *testing.T) {
+	m := NewManager("secret", time.Hour)
+	token, err := m.Generate(42)
+	require.NoError(t, err)
+
+	expired, err := NewManager("secret", -time.Hour).Generate(42)
+	require.NoError(t, err)
+
+	foreign, err := NewManager("another secret", time.Hour).Generate(42)
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name   string
+		token  string
+		userID int32
+		err    error
+	}{
+		{
+			name:   "OK",
+			token:  token,
+			userID: 42,
+		},
+		{
+			name:  "Failed if token is expired",
+			token: expired,
+			err:   ErrExpiredToken,
+		},
+		{
+			name:  "Failed if token is signed by another secret",
+			token: foreign,
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "Failed if payload is changed",
+			token: "eyJ1c2VyX2lkIjoxLCJleHAiOjk5OTk5OTk5OTl9" + token[len(token)-44:],
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "Failed if token is malformed",
+			token: "token",
+			err:   ErrInvalidToken,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			userID, err := m.Parse(testCase.token)
+			assert.Equal(t, testCase.err, err)
+			assert.Equal(t, testCase.userID, userID)
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("password")
+	require.NoError(t, err)
+	assert.True(t, CheckPassword(hash, "password"))
+	assert.False(t, CheckPassword(hash, "Password"))
+}
+
+func TestUserIDFromContext(t *testing.T) {
+	_, ok := UserIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	userID, ok := UserIDFromContext(WithUserID(context.Background(), 7))
+	assert.True(t, ok)
+	assert.Equal(t, int32(7), userID)
+}
@@ -1,14 +1,33 @@
 // Package config has a configuration structure
 package config
 
+import "time"
+
 // Config contains configuration data
 type Config struct {
+	Storage         string `env:"STORAGE" envDefault:"postgres"`       // postgres or memory
+	RebuildBalances bool   `env:"REBUILD_BALANCES" envDefault:"false"` // sets balances to the sums of the ledger at startup
+	Leverage        int32  `env:"LEVERAGE" envDefault:"1"`             // leverage of new accounts, from 1 to 500
+
+	MarginCallLevel float64 `env:"MARGIN_CALL_LEVEL" envDefault:"100"` // margin level in percent which the user is warned at
+	StopOutLevel    float64 `env:"STOP_OUT_LEVEL" envDefault:"50"`     // margin level in percent which positions are closed at
+
+	QuoteTTL time.Duration `env:"QUOTE_TTL" envDefault:"1m"` // positions don't open and close by an older price
+
+	RiskWorkers   int `env:"RISK_WORKERS" envDefault:"8"`       // workers which close positions by prices
+	RiskQueueSize int `env:"RISK_QUEUE_SIZE" envDefault:"1024"` // jobs which may wait for a worker before prices wait
+
 	UsernamePostgres string `env:"POSTGRES_USER" envDefault:"postgres"`
 	PasswordPostgres string `env:"POSTGRES_PASSWORD" envDefault:"testpassword"`
 	HostPostgres     string `env:"POSTGRES_USER" envDefault:"localhost"`
 	PortPostgres     string `env:"POSTGRES_USER" envDefault:"5432"`
 	DBNamePostgres   string `env:"POSTGRES_DB" envDefault:"postgres"`
 
+	MaxConnsPostgres          int32         `env:"POSTGRES_MAX_CONNS" envDefault:"10"`
+	MinConnsPostgres          int32         `env:"POSTGRES_MIN_CONNS" envDefault:"2"`
+	HealthCheckPeriodPostgres time.Duration `env:"POSTGRES_HEALTH_CHECK_PERIOD" envDefault:"1m"`
+	StatementTimeoutPostgres  time.Duration `env:"POSTGRES_STATEMENT_TIMEOUT" envDefault:"5s"`
+
 	ServerRedisCache string `env:"SERVER" envDefault:"server1"`
 	HostRedisCache   string `env:"HOST" envDefault:"localhost"`
 	PortRedisCache   string `env:"PORT" envDefault:"6379"`
@@ -16,6 +35,14 @@ type Config struct {
 	HostGrpcClient string `env:"HOST_GRPC" envDefault:"localhost"`
 	PortGrpcClient string `env:"PORT_GRPC" envDefault:"10000"`
 
+	PricerMinBackoff time.Duration `env:"PRICER_MIN_BACKOFF" envDefault:"500ms"` // wait before the first reconnect to the pricer
+	PricerMaxBackoff time.Duration `env:"PRICER_MAX_BACKOFF" envDefault:"30s"`   // the wait doubles up to it until a price arrives
+	PricerStaleAfter time.Duration `env:"PRICER_STALE_AFTER" envDefault:"30s"`   // time without prices which is alerted
+
 	HostGrpcServer string `env:"HOST_GRPC_SERVER" envDefault:"localhost"`
 	PortGrpcServer string `env:"PORT_GRPC_SERVER" envDefault:"11000"`
+
+	TokenSecret string        `env:"TOKEN_SECRET,notEmpty"` // the broker doesn't start without it
+	TokenTTL    time.Duration `env:"TOKEN_TTL" envDefault:"24h"`
+	AdminKey    string        `env:"ADMIN_KEY"` // admin methods are disabled if it is empty
 }
@@ -0,0 +1,20 @@
+// Package feed sends values to slow readers without blocking the writer
+package feed
+
+// Send sends the value to the chan without blocking. If the buffer of the chan is full, its oldest value is dropped
+// in favor of the latest one
+func Send[T any](ch chan T, v T) {
+	select {
+	case ch <- v:
+		return
+	default:
+	}
+	select {
+	case <-ch:
+	default:
+	}
+	select {
+	case ch <- v:
+	default:
+	}
+}
@@ -0,0 +1,46 @@
+package feed
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	testTable := []struct {
+		name   string
+		sent   int
+		expect []int
+	}{
+		{
+			name:   "Keeps all values if buffer isn't full",
+			sent:   2,
+			expect: []int{1, 2},
+		},
+		{
+			name:   "Keeps all values if buffer is just full",
+			sent:   3,
+			expect: []int{1, 2, 3},
+		},
+		{
+			name:   "Drops the oldest values if buffer is full",
+			sent:   5,
+			expect: []int{3, 4, 5},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ch := make(chan int, 3)
+			for i := 1; i <= testCase.sent; i++ {
+				Send(ch, i)
+			}
+			close(ch)
+			got := make([]int, 0, len(ch))
+			for v := range ch {
+				got = append(got, v)
+			}
+			assert.Equal(t, testCase.expect, got)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package interceptor checks session tokens of grpc requests
+package interceptor
+
+import (
+	"github.com/chucky-1/broker/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"context"
+	"crypto/subtle"
+	"strings"
+)
+
+// Auth checks the token of every request except public methods and puts id of the user into the context.
+// Admin methods are checked by the admin key instead of the token
+type Auth struct {
+	tokens   *auth.Manager
+	public   map[string]struct{}
+	adminKey string
+	admin    map[string]struct{}
+}
+
+// NewAuth is constructor. Public methods are full names like "/pgrpc.Broker/SignIn"
+func NewAuth(tokens *auth.Manager, public ...string) *Auth {
+	a := Auth{
+		tokens: tokens,
+		public: make(map[string]struct{}, len(public)),
+	}
+	for _, method := range public {
+		a.public[method] = struct{}{}
+	}
+	return &a
+}
+
+// WithAdmin sets the key which admin methods must send as the token. Admin methods are refused if the key is empty
+func (a *Auth) WithAdmin(key string, methods ...string) *Auth {
+	a.adminKey = key
+	a.admin = make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		a.admin[method] = struct{}{}
+	}
+	return a
+}
+
+// Unary returns interceptor for unary requests
+func (a *Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := a.public[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+		if _, ok := a.admin[info.FullMethod]; ok {
+			if err := a.authorizeAdmin(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+		ctx, err := a.authorize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns interceptor for streaming requests
+func (a *Auth) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := a.public[info.FullMethod]; ok {
+			return handler(srv, ss)
+		}
+		if _, ok := a.admin[info.FullMethod]; ok {
+			if err := a.authorizeAdmin(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}
+		ctx, err := a.authorize(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize reads the token from "authorization" metadata
+func (a *Auth) authorize(ctx context.Context) (context.Context, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := a.tokens.Parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return auth.WithUserID(ctx, userID), nil
+}
+
+// authorizeAdmin compares the token with the admin key
+func (a *Auth) authorizeAdmin(ctx context.Context) error {
+	if a.adminKey == "" {
+		return status.Error(codes.PermissionDenied, "admin methods are disabled")
+	}
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.adminKey)) != 1 {
+		return status.Error(codes.PermissionDenied, "wrong admin key")
+	}
+	return nil
+}
+
+// tokenFromContext reads "authorization" metadata without "Bearer " prefix
+func tokenFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "token is not provided")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "token is not provided")
+	}
+	return strings.TrimPrefix(values[0], "Bearer "), nil
+}
+
+// serverStream replaces the context of the stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with id of the user
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"github.com/chucky-1/broker/internal/pricer"
+	"github.com/chucky-1/broker/internal/request"
+	"github.com/chucky-1/broker/internal/service"
+	"github.com/chucky-1/broker/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"context"
+)
+
+// Admin contains methods of the administrator on service side of grpc
+type Admin struct {
+	protocol.UnimplementedAdminServer
+	srv    *service.Service
+	prices *pricer.Client
+}
+
+// NewAdmin is constructor
+func NewAdmin(srv *service.Service, prices *pricer.Client) *Admin {
+	return &Admin{srv: srv, prices: prices}
+}
+
+// AddSymbol adds tradable symbol
+func (a *Admin) AddSymbol(ctx context.Context, r *protocol.AddSymbolRequest) (*protocol.AddSymbolResponse, error) {
+	tickSize, err := toAmount(r.TickSize)
+	if err != nil {
+		return nil, err
+	}
+	symbolID, err := a.srv.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   r.Ticker,
+		Title:    r.Title,
+		LotSize:  r.LotSize,
+		TickSize: tickSize,
+		Currency: r.Currency,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	log.Infof("symbol %s is added with id %d", r.Ticker, symbolID)
+	return &protocol.AddSymbolResponse{SymbolId: symbolID}, nil
+}
+
+// DisableSymbol forbids trading the symbol
+func (a *Admin) DisableSymbol(ctx context.Context, r *protocol.DisableSymbolRequest) (*protocol.DisableSymbolResponse, error) {
+	if err := a.srv.DisableSymbol(ctx, r.SymbolId); err != nil {
+		return nil, statusError(err)
+	}
+	log.Infof("symbol %d is disabled", r.SymbolId)
+	return &protocol.DisableSymbolResponse{}, nil
+}
+
+// SetLeverage changes leverage of the user's new positions
+func (a *Admin) SetLeverage(ctx context.Context, r *protocol.SetLeverageRequest) (*protocol.SetLeverageResponse, error) {
+	if err := a.srv.SetLeverage(ctx, r.UserId, r.Leverage); err != nil {
+		return nil, statusError(err)
+	}
+	log.Infof("leverage of user %d is set to %d", r.UserId, r.Leverage)
+	return &protocol.SetLeverageResponse{}, nil
+}
+
+// GetEngineStats returns counters of the engine which closes positions by prices
+func (a *Admin) GetEngineStats(ctx context.Context, r *protocol.GetEngineStatsRequest) (*protocol.GetEngineStatsResponse, error) {
+	stats := a.srv.EngineStats()
+	return &protocol.GetEngineStatsResponse{
+		Workers:     int32(stats.Workers),
+		Capacity:    int32(stats.Capacity),
+		Queued:      int32(stats.Queued),
+		Positions:   int32(stats.Positions),
+		Ticks:       stats.Ticks,
+		Checked:     stats.Checked,
+		Submitted:   stats.Submitted,
+		Done:        stats.Done,
+		Failed:      stats.Failed,
+		Blocked:     stats.Blocked,
+		BlockedTime: stats.BlockedTime.Milliseconds(),
+	}, nil
+}
+
+// GetPricerStatus returns the state of the connection to the pricer
+func (a *Admin) GetPricerStatus(ctx context.Context, r *protocol.GetPricerStatusRequest) (*protocol.GetPricerStatusResponse, error) {
+	status := a.prices.Status()
+	response := &protocol.GetPricerStatusResponse{
+		State:      status.State.String(),
+		Since:      status.Since.Unix(),
+		Reconnects: status.Reconnects,
+		Stale:      status.Stale,
+	}
+	if !status.LastTick.IsZero() {
+		response.LastTick = status.LastTick.Unix()
+	}
+	return response, nil
+}
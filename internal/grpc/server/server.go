@@ -2,94 +2,470 @@
 package server
 
 import (
+	"github.com/chucky-1/broker/internal/auth"
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
 	"github.com/chucky-1/broker/internal/request"
 	"github.com/chucky-1/broker/internal/service"
 	"github.com/chucky-1/broker/protocol"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"context"
-	"fmt"
+	"errors"
+	"time"
 )
 
 // Server contains methods of application on service side of grpc
 type Server struct {
 	protocol.UnimplementedBrokerServer
-	srv *service.Service
+	srv    *service.Service
+	tokens *auth.Manager
 }
 
 // NewServer is constructor
-func NewServer(srv *service.Service) *Server {
-	return &Server{srv: srv}
+func NewServer(srv *service.Service, tokens *auth.Manager) *Server {
+	return &Server{srv: srv, tokens: tokens}
 }
 
 // SignUp registers a new user
 func (s *Server) SignUp(ctx context.Context, r *protocol.SignUpRequest) (*protocol.SignUpResponse, error) {
-	id, err := s.srv.SignUp(ctx, r.Deposit)
+	deposit, err := toAmount(r.Deposit)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.srv.SignUp(ctx, &request.SignUp{
+		Login:    r.Login,
+		Password: r.Password,
+		Deposit:  deposit,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return &protocol.SignUpResponse{UserId: id}, nil
 }
 
-// SignIn logs into your account
+// SignIn logs into your account. Returns the token which must be sent in "authorization" metadata
 func (s *Server) SignIn(ctx context.Context, r *protocol.SignInRequest) (*protocol.SignInResponse, error) {
-	return &protocol.SignInResponse{}, nil
+	id, err := s.srv.SignIn(ctx, r.Login, r.Password)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	token, err := s.tokens.Generate(id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &protocol.SignInResponse{Token: token, UserId: id}, nil
 }
 
 // OpenPosition opens a position
 func (s *Server) OpenPosition(ctx context.Context, r *protocol.OpenPositionRequest) (*protocol.OpenPositionResponse, error) {
-	positionID, err := s.srv.OpenPosition(ctx, &request.OpenPositionService{
-		UserID:     r.UserId,
-		SymbolID:   r.SymbolId,
-		Price:      r.Price,
-		Count:      r.Count,
-		StopLoss:   r.StopLoss,
-		TakeProfit: r.TakeProfit,
-		IsBuy:      r.IsBuy,
-	})
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err.Error() == "user didn't find. Please, sign up" {
-			return nil, err
-		}
-		if err.Error() == fmt.Sprintf("symbol with id %d didn't find", r.SymbolId) {
-			return nil, err
-		}
-		if err.Error() == "not enough money" {
-			return nil, err
-		}
-		if err.Error() == "price changed. Try again" {
-			return nil, err
-		}
-		log.Error(err)
 		return nil, err
 	}
+	var mp moneyParser
+	req := &request.OpenPositionService{
+		UserID:       userID,
+		SymbolID:     r.SymbolId,
+		Price:        mp.amount(r.Price),
+		Count:        r.Count,
+		StopLoss:     mp.amount(r.StopLoss),
+		TakeProfit:   mp.amount(r.TakeProfit),
+		TrailingStop: mp.amount(r.TrailingStop),
+		IsBuy:        r.IsBuy,
+	}
+	if mp.err != nil {
+		return nil, mp.err
+	}
+	positionID, err := s.srv.OpenPosition(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
 	return &protocol.OpenPositionResponse{PositionId: positionID}, nil
 }
 
+// ModifyPosition changes stop loss and take profit of a position
+func (s *Server) ModifyPosition(ctx context.Context, r *protocol.ModifyPositionRequest) (*protocol.ModifyPositionResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var mp moneyParser
+	req := &request.ModifyPosition{
+		UserID:     userID,
+		PositionID: r.PositionId,
+		StopLoss:   mp.amount(r.StopLoss),
+		TakeProfit: mp.amount(r.TakeProfit),
+	}
+	if mp.err != nil {
+		return nil, mp.err
+	}
+	err = s.srv.ModifyPosition(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.ModifyPositionResponse{}, nil
+}
+
 // ClosePosition closes a position
 func (s *Server) ClosePosition(ctx context.Context, r *protocol.ClosePositionRequest) (*protocol.ClosePositionResponse, error) {
-	err := s.srv.ClosePosition(ctx, r.PositionId)
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	positionID, err := s.srv.ClosePosition(ctx, &request.ClosePositionService{
+		UserID:     userID,
+		PositionID: r.PositionId,
+		Count:      r.Count,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.ClosePositionResponse{PositionId: positionID}, nil
+}
+
+// PlaceLimitOrder places a pending order which opens a position at the limit price
+func (s *Server) PlaceLimitOrder(ctx context.Context, r *protocol.PlaceLimitOrderRequest) (*protocol.PlaceLimitOrderResponse, error) {
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("you did not open a position with id %d", r.PositionId) {
-			return nil, err
-		}
-		log.Error(err)
 		return nil, err
 	}
-	return &protocol.ClosePositionResponse{}, nil
+	var mp moneyParser
+	req := &request.PlaceOrder{
+		UserID:     userID,
+		SymbolID:   r.SymbolId,
+		Type:       model.OrderLimit,
+		Price:      mp.amount(r.Price),
+		Count:      r.Count,
+		StopLoss:   mp.amount(r.StopLoss),
+		TakeProfit: mp.amount(r.TakeProfit),
+		IsBuy:      r.IsBuy,
+	}
+	if mp.err != nil {
+		return nil, mp.err
+	}
+	orderID, err := s.srv.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.PlaceLimitOrderResponse{OrderId: orderID}, nil
+}
+
+// PlaceOrder places a pending order of any type
+func (s *Server) PlaceOrder(ctx context.Context, r *protocol.PlaceOrderRequest) (*protocol.PlaceOrderResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var mp moneyParser
+	req := &request.PlaceOrder{
+		UserID:     userID,
+		SymbolID:   r.SymbolId,
+		Type:       model.OrderType(r.OrderType),
+		Price:      mp.amount(r.Price),
+		Count:      r.Count,
+		StopLoss:   mp.amount(r.StopLoss),
+		TakeProfit: mp.amount(r.TakeProfit),
+		IsBuy:      r.IsBuy,
+	}
+	if mp.err != nil {
+		return nil, mp.err
+	}
+	orderID, err := s.srv.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.PlaceOrderResponse{OrderId: orderID}, nil
 }
 
 // SetBalance changes user's balance
 func (s *Server) SetBalance(ctx context.Context, r *protocol.SetBalanceRequest) (*protocol.SetBalanceResponse, error) {
-	err := s.srv.SetBalance(ctx, r.UserId, r.Sum)
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sum, err := toAmount(r.Sum)
 	if err != nil {
 		return nil, err
 	}
+	err = s.srv.SetBalance(ctx, userID, sum)
+	if err != nil {
+		return nil, statusError(err)
+	}
 	return &protocol.SetBalanceResponse{}, nil
 }
 
 // GetBalance returns user's balance
 func (s *Server) GetBalance(ctx context.Context, r *protocol.GetBalanceRequest) (*protocol.GetBalanceResponse, error) {
-	balance := s.srv.GetBalance(ctx, r.UserId)
-	return &protocol.GetBalanceResponse{Sum: balance}, nil
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.srv.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.GetBalanceResponse{Sum: toMoney(balance)}, nil
+}
+
+// GetMargin returns equity and margin of the user
+func (s *Server) GetMargin(ctx context.Context, r *protocol.GetMarginRequest) (*protocol.GetMarginResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	margin, err := s.srv.GetMargin(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.GetMarginResponse{
+		Leverage:    margin.Leverage,
+		Balance:     toMoney(margin.Balance),
+		Equity:      toMoney(margin.Equity),
+		UsedMargin:  toMoney(margin.UsedMargin),
+		FreeMargin:  toMoney(margin.FreeMargin),
+		MarginLevel: margin.MarginLevel,
+	}, nil
+}
+
+// SubscribePrices sends prices of the chosen symbols until the client disconnects
+func (s *Server) SubscribePrices(r *protocol.SubscribePricesRequest, stream protocol.Broker_SubscribePricesServer) error {
+	prices, err := s.srv.SubscribePrices(stream.Context(), r.SymbolIds)
+	if err != nil {
+		return statusError(err)
+	}
+	for price := range prices {
+		err = stream.Send(&protocol.Price{
+			SymbolId: price.ID,
+			Bid:      toMoney(price.Bid),
+			Ask:      toMoney(price.Ask),
+			Time:     price.Time,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamPositions sends current prices and pnl of user's open positions and events of their closing
+func (s *Server) StreamPositions(r *protocol.StreamPositionsRequest, stream protocol.Broker_StreamPositionsServer) error {
+	userID, err := userIDFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+	events, err := s.srv.StreamPositions(stream.Context(), userID)
+	if err != nil {
+		return statusError(err)
+	}
+	for event := range events {
+		err = stream.Send(&protocol.PositionEvent{
+			PositionId:    event.PositionID,
+			SymbolId:      event.SymbolID,
+			PriceClose:    toMoney(event.PriceClose),
+			Pnl:           toMoney(event.Pnl),
+			Closed:        event.Closed,
+			CloseReason:   protocol.CloseReason(event.Reason),
+			MarginCall:    event.MarginCall,
+			MarginLevel:   event.MarginLevel,
+			OrderId:       event.OrderID,
+			OrderRejected: event.OrderRejected,
+			RejectReason:  event.RejectReason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAccountHistory returns a page of movements of the user's balance
+func (s *Server) GetAccountHistory(ctx context.Context, r *protocol.GetAccountHistoryRequest) (*protocol.GetAccountHistoryResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &request.AccountHistory{
+		UserID:   userID,
+		SymbolID: r.SymbolId,
+		Cursor:   r.PageToken,
+		Limit:    r.PageSize,
+	}
+	if r.From != 0 {
+		req.From = time.Unix(r.From, 0)
+	}
+	if r.To != 0 {
+		req.To = time.Unix(r.To, 0)
+	}
+	for _, t := range r.EntryTypes {
+		req.Types = append(req.Types, model.EntryType(t))
+	}
+	entries, next, err := s.srv.GetAccountHistory(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	response := &protocol.GetAccountHistoryResponse{
+		Entries:       make([]*protocol.AccountEntry, 0, len(entries)),
+		NextPageToken: next,
+	}
+	for _, e := range entries {
+		response.Entries = append(response.Entries, &protocol.AccountEntry{
+			EntryId:     e.ID,
+			EntryType:   protocol.EntryType(e.Type),
+			Amount:      toMoney(e.Amount),
+			Time:        e.Time.Unix(),
+			PositionId:  e.PositionID,
+			OrderId:     e.OrderID,
+			SymbolId:    e.SymbolID,
+			SymbolTitle: e.SymbolTitle,
+			Pnl:         toMoney(e.Pnl),
+		})
+	}
+	return response, nil
+}
+
+// ListOpenPositions returns a page of the user's open positions
+func (s *Server) ListOpenPositions(ctx context.Context, r *protocol.ListPositionsRequest) (*protocol.ListPositionsResponse, error) {
+	return s.listPositions(ctx, r, s.srv.ListOpenPositions)
+}
+
+// ListClosedPositions returns a page of the user's closed positions
+func (s *Server) ListClosedPositions(ctx context.Context, r *protocol.ListPositionsRequest) (*protocol.ListPositionsResponse, error) {
+	return s.listPositions(ctx, r, s.srv.ListClosedPositions)
+}
+
+func (s *Server) listPositions(ctx context.Context, r *protocol.ListPositionsRequest,
+	list func(context.Context, *request.ListPositions) ([]*model.Position, int32, error)) (*protocol.ListPositionsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	positions, next, err := list(ctx, &request.ListPositions{UserID: userID, Cursor: r.PageToken, Limit: r.PageSize})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	response := &protocol.ListPositionsResponse{
+		Positions:     make([]*protocol.Position, 0, len(positions)),
+		NextPageToken: next,
+	}
+	for _, p := range positions {
+		position := &protocol.Position{
+			PositionId:   p.ID,
+			SymbolId:     p.SymbolID,
+			SymbolTitle:  p.SymbolTitle,
+			Count:        p.Count,
+			IsBuy:        p.IsBuy,
+			PriceOpen:    toMoney(p.PriceOpen),
+			TimeOpen:     p.TimeOpen.Unix(),
+			StopLoss:     toMoney(p.StopLoss),
+			TakeProfit:   toMoney(p.TakeProfit),
+			TrailingStop: toMoney(p.TrailingStop),
+			PriceClose:   toMoney(p.PriceClose),
+			Pnl:          toMoney(p.Pnl),
+			CloseReason:  protocol.CloseReason(p.CloseReason),
+		}
+		if !p.TimeClose.IsZero() {
+			position.TimeClose = p.TimeClose.Unix()
+		}
+		response.Positions = append(response.Positions, position)
+	}
+	return response, nil
+}
+
+// ListSymbols returns all symbols of the broker
+func (s *Server) ListSymbols(ctx context.Context, r *protocol.ListSymbolsRequest) (*protocol.ListSymbolsResponse, error) {
+	symbols := s.srv.ListSymbols(ctx)
+	response := &protocol.ListSymbolsResponse{Symbols: make([]*protocol.Symbol, 0, len(symbols))}
+	for _, symbol := range symbols {
+		response.Symbols = append(response.Symbols, &protocol.Symbol{
+			SymbolId: symbol.ID,
+			Ticker:   symbol.Ticker,
+			Title:    symbol.Title,
+			Tradable: symbol.Tradable,
+			LotSize:  symbol.LotSize,
+			TickSize: toMoney(symbol.TickSize),
+			Currency: symbol.Currency,
+		})
+	}
+	return response, nil
+}
+
+// GetQuote returns the last price of the symbol
+func (s *Server) GetQuote(ctx context.Context, r *protocol.GetQuoteRequest) (*protocol.Price, error) {
+	price, err := s.srv.GetQuote(ctx, r.SymbolId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &protocol.Price{
+		SymbolId: price.ID,
+		Bid:      toMoney(price.Bid),
+		Ask:      toMoney(price.Ask),
+		Time:     price.Time,
+	}, nil
+}
+
+// statusError converts known errors of the service into grpc statuses. Unknown errors are logged
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPositionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrSymbolNotFound), errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNoQuote):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, service.ErrNotEnoughMargin), errors.Is(err, service.ErrSymbolNotTradable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrPriceChanged):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, money.ErrOverflow):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Error(err)
+		return err
+	}
+}
+
+// userIDFromContext returns id of the user which has been authenticated by the interceptor
+func userIDFromContext(ctx context.Context) (int32, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "user isn't authenticated")
+	}
+	return userID, nil
+}
+
+// moneyParser converts money of the protocol into amounts and keeps the first error
+type moneyParser struct {
+	err error
+}
+
+func (p *moneyParser) amount(m *protocol.Money) money.Amount {
+	a, err := toAmount(m)
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return a
+}
+
+// toAmount converts money of the protocol into amount. Absent money is zero
+func toAmount(m *protocol.Money) (money.Amount, error) {
+	if m == nil {
+		return 0, nil
+	}
+	a, err := money.FromUnitsNanos(m.Units, m.Nanos)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return a, nil
+}
+
+// toMoney converts amount into money of the protocol
+func toMoney(a money.Amount) *protocol.Money {
+	units, nanos := a.UnitsNanos()
+	return &protocol.Money{Units: units, Nanos: nanos}
 }
@@ -1,40 +1,293 @@
 // Package model has struct of essence
 package model
 
-import "time"
+import (
+	"github.com/chucky-1/broker/internal/money"
 
-// Symbol is struct
+	"fmt"
+	"time"
+)
+
+// Symbol is an instrument which the broker trades. Count of a position is a multiple of LotSize,
+// prices of orders and levels of positions are multiples of TickSize
 type Symbol struct {
-	ID    int32
-	Title string
+	ID       int32
+	Ticker   string
+	Title    string
+	Tradable bool
+	LotSize  int32
+	TickSize money.Amount
+	Currency string
+}
+
+// SymbolEvent tells that a symbol has been added or disabled
+type SymbolEvent struct {
+	SymbolID int32
+	Tradable bool
 }
 
 // Price contains fields that describe the shares of companies
 type Price struct {
 	ID   int32
-	Bid  float32
-	Ask  float32
+	Bid  money.Amount
+	Ask  money.Amount
 	Time int64
 }
 
 // User is model of user
 type User struct {
-	ID      int32
-	Balance float32
+	ID           int32
+	Login        string
+	PasswordHash string
+	Balance      money.Amount
+	Leverage     int32 // a position reserves 1/Leverage of its price as margin
+}
+
+// Margin describes how much of the user's money is reserved by open positions. Equity is the balance with pnl
+// of open positions, FreeMargin is the equity which may be reserved by new positions
+type Margin struct {
+	Leverage    int32
+	Balance     money.Amount
+	Equity      money.Amount
+	UsedMargin  money.Amount
+	FreeMargin  money.Amount
+	MarginLevel float64 // equity to used margin in percent, zero without open positions
+}
+
+// MarginLevels are margin levels in percent which the broker controls accounts by. Below MarginCall the user is
+// warned, below StopOut positions are closed from the worst loss until the margin level rises to StopOut
+type MarginLevels struct {
+	MarginCall float64
+	StopOut    float64
+}
+
+// MarginEventType is a step of the margin control
+type MarginEventType int32
+
+const (
+	// MarginCallWarning means that the user has been warned that the margin level is below the margin-call level
+	MarginCallWarning MarginEventType = iota
+	// MarginStopOut means that a position has been closed because the margin level is below the stop-out level
+	MarginStopOut
+)
+
+// String returns the name of the type which is stored in the database
+func (t MarginEventType) String() string {
+	switch t {
+	case MarginCallWarning:
+		return "margin_call"
+	case MarginStopOut:
+		return "stop_out"
+	default:
+		return fmt.Sprintf("MarginEventType(%d)", int32(t))
+	}
+}
+
+// MarginEvent is a step of the margin control with the state of the account which has caused it.
+// PositionID is the closed position, zero for the warning
+type MarginEvent struct {
+	UserID      int32
+	Type        MarginEventType
+	PositionID  int32
+	Equity      money.Amount
+	UsedMargin  money.Amount
+	MarginLevel float64
+	Time        time.Time
 }
 
 // Position is model of position
 type Position struct {
+	ID           int32
+	UserID       int32
+	SymbolID     int32
+	SymbolTitle  string
+	Count        int32
+	PriceOpen    money.Amount
+	TimeOpen     time.Time
+	BidClose     money.Amount
+	AskClose     money.Amount
+	StopLoss     money.Amount
+	TakeProfit   money.Amount
+	TrailingStop money.Amount // distance between the best price and StopLoss, zero means that StopLoss is fixed
+	IsBuy        bool
+	Margin       money.Amount // the part of the balance reserved while the position is open
+	PriceClose   money.Amount
+	TimeClose    time.Time
+	Pnl          money.Amount
+	CloseReason  CloseReason
+	ParentID     int32 // id of the position which the closed part has been split from, zero for a whole position
+}
+
+// CloseReason explains why a position has been closed
+type CloseReason int32
+
+const (
+	// CloseManual means that the user has closed the position
+	CloseManual CloseReason = iota
+	// CloseStopLoss means that the price has reached stop loss
+	CloseStopLoss
+	// CloseTakeProfit means that the price has reached take profit
+	CloseTakeProfit
+	// CloseMarginCall means that the user hasn't had enough money to keep the position
+	CloseMarginCall
+	// CloseAdmin means that an administrator has closed the position
+	CloseAdmin
+)
+
+// String returns the name of the reason which is stored in the database
+func (r CloseReason) String() string {
+	switch r {
+	case CloseManual:
+		return "manual"
+	case CloseStopLoss:
+		return "stop_loss"
+	case CloseTakeProfit:
+		return "take_profit"
+	case CloseMarginCall:
+		return "margin_call"
+	case CloseAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("CloseReason(%d)", int32(r))
+	}
+}
+
+// ParseCloseReason returns the reason by its name
+func ParseCloseReason(name string) (CloseReason, error) {
+	for r := CloseManual; r <= CloseAdmin; r++ {
+		if r.String() == name {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown close reason %q", name)
+}
+
+// PositionEvent describes the current state of an open position or its closing. The margin-call warning
+// isn't about a position, it has MarginCall set and the margin level of the account. The rejection of a pending
+// order has OrderRejected set, the id of the order and the reason
+type PositionEvent struct {
+	PositionID    int32
+	SymbolID      int32
+	PriceClose    money.Amount
+	Pnl           money.Amount
+	Closed        bool
+	Reason        CloseReason
+	MarginCall    bool
+	MarginLevel   float64
+	OrderID       int32
+	OrderRejected bool
+	RejectReason  string
+}
+
+// OrderType defines when a pending order turns into a position
+type OrderType int32
+
+const (
+	// OrderLimit opens a position at Price or better
+	OrderLimit OrderType = iota
+	// OrderStop opens a position at the market price once the price moves through Price
+	OrderStop
+	// OrderMarketIfTouched opens a position at the market price once the price touches Price
+	OrderMarketIfTouched
+)
+
+// Order is model of pending order. It turns into a position when the price reaches Price
+type Order struct {
+	ID         int32
+	UserID     int32
+	SymbolID   int32
+	Type       OrderType
+	Count      int32
+	Price      money.Amount
+	StopLoss   money.Amount
+	TakeProfit money.Amount
+	IsBuy      bool
+	TimeCreate time.Time
+}
+
+// EntryType explains why a balance has changed
+type EntryType int32
+
+const (
+	// EntryDeposit is money paid in by the user
+	EntryDeposit EntryType = iota
+	// EntryWithdrawal is money paid out to the user
+	EntryWithdrawal
+	// EntryPositionOpen marks the opening of a position. Its amount is zero since the position only reserves margin
+	EntryPositionOpen
+	// EntryPositionClose is money paid or received when a position closes
+	EntryPositionClose
+	// EntryAdjustment is a correction made by an administrator. 4 is reserved in the protocol
+	EntryAdjustment EntryType = iota + 1
+)
+
+// AccountUser is the account of the user's balance. Every movement is booked to it and to the contra account
+// of the entry type, so the amounts of a transaction sum to zero
+const AccountUser = "user"
+
+// String returns the name of the type which is stored in the database
+func (t EntryType) String() string {
+	switch t {
+	case EntryDeposit:
+		return "deposit"
+	case EntryWithdrawal:
+		return "withdrawal"
+	case EntryPositionOpen:
+		return "position_open"
+	case EntryPositionClose:
+		return "position_close"
+	case EntryAdjustment:
+		return "adjustment"
+	default:
+		return fmt.Sprintf("EntryType(%d)", int32(t))
+	}
+}
+
+// ContraAccount returns the account which takes the other side of the user's entry
+func (t EntryType) ContraAccount() string {
+	switch t {
+	case EntryDeposit, EntryWithdrawal:
+		return "cash"
+	case EntryPositionOpen, EntryPositionClose:
+		return "trading"
+	default:
+		return "adjustments"
+	}
+}
+
+// ParseEntryType returns the type by its name
+func ParseEntryType(name string) (EntryType, error) {
+	for t := EntryDeposit; t <= EntryAdjustment; t++ {
+		if t.String() == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown entry type %q", name)
+}
+
+// LedgerEntry is one side of a balance movement. PositionID and OrderID link it to its cause, zero means no link
+type LedgerEntry struct {
+	ID            int32
+	TransactionID int32
+	UserID        int32
+	Account       string
+	Type          EntryType
+	Amount        money.Amount
+	PositionID    int32
+	OrderID       int32
+	Time          time.Time
+}
+
+// AccountEntry is a movement of the user's balance with the position which caused it.
+// Pnl is realized pnl of the closed position
+type AccountEntry struct {
 	ID          int32
-	UserID      int32
+	Type        EntryType
+	Amount      money.Amount
+	Time        time.Time
+	PositionID  int32
+	OrderID     int32
 	SymbolID    int32
 	SymbolTitle string
-	Count       int32
-	PriceOpen   float32
-	TimeOpen    time.Time
-	BidClose    float32
-	AskClose    float32
-	StopLoss    float32
-	TakeProfit  float32
-	IsBuy       bool
+	Pnl         money.Amount
 }
@@ -0,0 +1,212 @@
+// Package money implements exact decimal arithmetic for balances, prices and pnl.
+//
+// Rounding rules:
+//   - Amount keeps Scale decimal places. Every value with more decimal places (a parsed string,
+//     nanos of the protocol, a float price of the pricer) is rounded half away from zero.
+//   - Addition, subtraction and multiplication by a count of shares are exact. A count which comes from a client
+//     is multiplied by MulChecked, it returns ErrOverflow instead of a wrapped product.
+//   - Division is rounded half away from zero.
+package money
+
+import (
+	"github.com/jackc/pgtype"
+
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale is a count of decimal places which Amount keeps
+const Scale = 6
+
+const (
+	unit          = 1000000    // 10^Scale
+	nanosPerUnit  = 1000000000 // nanos in one unit of the protocol
+	nanosPerMicro = nanosPerUnit / unit
+)
+
+var (
+	// ErrInvalidAmount means that the value can't be converted into Amount
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrOverflow means that a result of arithmetic doesn't fit into Amount
+	ErrOverflow = errors.New("amount overflow")
+)
+
+// Amount is a fixed-point decimal number with Scale decimal places. Amount(1) is 0.000001.
+// Multiply amounts only by Mul, because a product of two amounts isn't an amount
+type Amount int64
+
+// New returns amount of whole units
+func New(units int64) Amount {
+	return Amount(units * unit)
+}
+
+// FromUnitsNanos returns amount from whole units and nano (10^-9) units. Both parts must have the same sign.
+// Nanos are rounded to Scale decimal places half away from zero
+func FromUnitsNanos(units int64, nanos int32) (Amount, error) {
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit || (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return 0, fmt.Errorf("%w: %d units and %d nanos", ErrInvalidAmount, units, nanos)
+	}
+	if units > math.MaxInt64/unit || units < math.MinInt64/unit {
+		return 0, fmt.Errorf("%w: %d units is out of range", ErrInvalidAmount, units)
+	}
+	return Amount(units*unit + roundDiv(int64(nanos), nanosPerMicro)), nil
+}
+
+// UnitsNanos returns whole units and nano (10^-9) units of the amount. Both parts have the same sign
+func (a Amount) UnitsNanos() (units int64, nanos int32) {
+	return int64(a) / unit, int32(int64(a)%unit) * nanosPerMicro
+}
+
+// FromFloat32 returns amount of the float. The float is taken as its shortest decimal representation,
+// so 0.1 is exactly 0.1, and then it is rounded to Scale decimal places half away from zero
+func FromFloat32(f float32) (Amount, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidAmount, f)
+	}
+	return Parse(strconv.FormatFloat(float64(f), 'f', -1, 32))
+}
+
+// Parse returns amount of a decimal string like "-12.345" or "12345e-3".
+// Extra decimal places are rounded half away from zero
+func Parse(s string) (Amount, error) {
+	str := s
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+		exp = e
+		str = str[:i]
+	}
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	// value = digits * 10^(exp - len(fracPart)), it is shifted to Scale decimal places
+	shift := exp - len(fracPart) + Scale
+	if shift >= 0 {
+		digits += strings.Repeat("0", shift)
+		shift = 0
+	}
+	cut := len(digits) + shift
+	kept, dropped := digits, ""
+	if cut <= 0 {
+		kept, dropped = "0", strings.Repeat("0", -cut)+digits
+	} else {
+		kept, dropped = digits[:cut], digits[cut:]
+	}
+	v, err := strconv.ParseInt("0"+strings.TrimLeft(kept, "0"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	if dropped != "" && dropped[0] >= '5' {
+		v++
+	}
+	if negative {
+		v = -v
+	}
+	return Amount(v), nil
+}
+
+// String returns the amount as a decimal string without trailing zeros
+func (a Amount) String() string {
+	v := int64(a)
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+	units, micros := v/unit, v%unit
+	if units < 0 {
+		units = -units
+	}
+	if micros < 0 {
+		micros = -micros
+	}
+	if micros == 0 {
+		return sign + strconv.FormatInt(units, 10)
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%06d", micros), "0")
+	return sign + strconv.FormatInt(units, 10) + "." + frac
+}
+
+// Mul returns the amount multiplied by a count. It is exact if the product fits into Amount
+func (a Amount) Mul(count int32) Amount {
+	return a * Amount(count)
+}
+
+// MulChecked returns the amount multiplied by a count or ErrOverflow if the product doesn't fit into Amount
+func (a Amount) MulChecked(count int32) (Amount, error) {
+	p := a * Amount(count)
+	if count != 0 && (p/Amount(count) != a || (count == -1 && a == math.MinInt64)) {
+		return 0, fmt.Errorf("%w: %s * %d", ErrOverflow, a, count)
+	}
+	return p, nil
+}
+
+// Div returns the amount divided by n. It is rounded half away from zero
+func (a Amount) Div(n int64) Amount {
+	return Amount(roundDiv(int64(a), n))
+}
+
+// Scan implements sql.Scanner. Postgres numeric comes as a string
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		amount, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*a = amount
+		return nil
+	case []byte:
+		return a.Scan(string(v))
+	case int64:
+		*a = New(v)
+		return nil
+	case nil:
+		*a = 0
+		return nil
+	default:
+		return fmt.Errorf("%w: can't scan %T", ErrInvalidAmount, src)
+	}
+}
+
+// Value implements driver.Valuer
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// EncodeText implements pgtype.TextEncoder, so pgx sends the amount as a decimal instead of its integer value
+func (a Amount) EncodeText(_ *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return append(buf, a.String()...), nil
+}
+
+// roundDiv returns a / b rounded half away from zero
+func roundDiv(a, b int64) int64 {
+	if b < 0 {
+		a, b = -a, -b
+	}
+	q, r := a/b, a%b
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
@@ -0,0 +1,167 @@
+package money
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testTable := []struct {
+		name   string
+		str    string
+		expect Amount
+		err    bool
+	}{
+		{name: "Integer", str: "12", expect: 12000000},
+		{name: "Decimal", str: "12.345", expect: 12345000},
+		{name: "Negative", str: "-0.5", expect: -500000},
+		{name: "Exponent of postgres numeric", str: "123456e-3", expect: 123456000},
+		{name: "Positive exponent", str: "15e2", expect: 1500000000},
+		{name: "Rounds half up", str: "1.2345675", expect: 1234568},
+		{name: "Rounds down", str: "1.2345674999", expect: 1234567},
+		{name: "Rounds half away from zero if negative", str: "-0.0000005", expect: -1},
+		{name: "Rounds tiny exponent to zero", str: "5e-10", expect: 0},
+		{name: "Failed if empty", str: "", err: true},
+		{name: "Failed if not a number", str: "1.2.3", err: true},
+		{name: "Failed if NaN", str: "NaN", err: true},
+		{name: "Failed if out of range", str: "10000000000000", err: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, err := Parse(testCase.str)
+			if testCase.err {
+				assert.True(t, errors.Is(err, ErrInvalidAmount))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expect, a)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	testTable := []struct {
+		amount Amount
+		expect string
+	}{
+		{amount: 0, expect: "0"},
+		{amount: New(7), expect: "7"},
+		{amount: 12345000, expect: "12.345"},
+		{amount: -500000, expect: "-0.5"},
+		{amount: 1, expect: "0.000001"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.expect, func(t *testing.T) {
+			assert.Equal(t, testCase.expect, testCase.amount.String())
+			a, err := Parse(testCase.amount.String())
+			require.NoError(t, err)
+			assert.Equal(t, testCase.amount, a)
+		})
+	}
+}
+
+func TestFromUnitsNanos(t *testing.T) {
+	testTable := []struct {
+		name   string
+		units  int64
+		nanos  int32
+		expect Amount
+		err    bool
+	}{
+		{name: "OK", units: 12, nanos: 345000000, expect: 12345000},
+		{name: "Negative", units: -1, nanos: -500000000, expect: -1500000},
+		{name: "Rounds half up", units: 0, nanos: 1500, expect: 2},
+		{name: "Rounds down", units: 0, nanos: 1499, expect: 1},
+		{name: "Rounds half away from zero if negative", units: 0, nanos: -500, expect: -1},
+		{name: "Failed if signs differ", units: 1, nanos: -1, err: true},
+		{name: "Failed if nanos overflow", units: 0, nanos: 1000000000, err: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, err := FromUnitsNanos(testCase.units, testCase.nanos)
+			if testCase.err {
+				assert.True(t, errors.Is(err, ErrInvalidAmount))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expect, a)
+		})
+	}
+}
+
+func TestAmount_UnitsNanos(t *testing.T) {
+	units, nanos := Amount(-1500001).UnitsNanos()
+	assert.Equal(t, int64(-1), units)
+	assert.Equal(t, int32(-500001000), nanos)
+}
+
+func TestFromFloat32(t *testing.T) {
+	a, err := FromFloat32(0.1)
+	require.NoError(t, err)
+	assert.Equal(t, Amount(100000), a, "float must be taken as its shortest decimal representation")
+
+	a, err = FromFloat32(1234.5677)
+	require.NoError(t, err)
+	assert.Equal(t, Amount(1234567700), a)
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	price, err := Parse("0.1")
+	require.NoError(t, err)
+
+	// 0.1 * 3 - 0.3 is not zero in floats
+	assert.Equal(t, Amount(0), price.Mul(3)-New(3).Div(10))
+	assert.Equal(t, Amount(3333333), New(10).Div(3))
+	assert.Equal(t, Amount(6666667), New(20).Div(3), "division is rounded half away from zero")
+	assert.Equal(t, Amount(-6666667), New(-20).Div(3), "division is rounded half away from zero")
+}
+
+func TestAmount_MulChecked(t *testing.T) {
+	testTable := []struct {
+		name   string
+		amount Amount
+		count  int32
+		expect Amount
+		err    bool
+	}{
+		{name: "OK", amount: New(60000), count: 3, expect: New(180000)},
+		{name: "Negative", amount: New(-5), count: 2, expect: New(-10)},
+		{name: "Zero count", amount: math.MaxInt64, count: 0},
+		{name: "Largest count", amount: New(1), count: math.MaxInt32, expect: New(math.MaxInt32)},
+		{name: "Overflow", amount: New(60000), count: 160000000, err: true},
+		{name: "Negative overflow", amount: New(-60000), count: 160000000, err: true},
+		{name: "Overflow of the smallest amount", amount: math.MinInt64, count: -1, err: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, err := testCase.amount.MulChecked(testCase.count)
+			if testCase.err {
+				assert.True(t, errors.Is(err, ErrOverflow))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expect, a)
+		})
+	}
+}
+
+func TestAmount_Scan(t *testing.T) {
+	var a Amount
+	require.NoError(t, a.Scan("123456e-3"))
+	assert.Equal(t, Amount(123456000), a)
+	require.NoError(t, a.Scan(nil))
+	assert.Equal(t, Amount(0), a)
+	assert.Error(t, a.Scan(1.5))
+
+	v, err := Amount(123456000).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "123.456", v)
+}
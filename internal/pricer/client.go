@@ -0,0 +1,291 @@
+// Package pricer receives prices from the pricer. It reconnects when the stream breaks, subscribes again to the
+// symbols which the broker trades and alerts when prices stop coming
+package pricer
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	protocol "github.com/chucky-1/pricer/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Actions of the pricer subscription
+const (
+	actionSubscribe   = 0
+	actionUnsubscribe = 1
+)
+
+// State is a state of the connection to the pricer
+type State int32
+
+const (
+	// StateConnecting means that the client is opening the stream and subscribing
+	StateConnecting State = iota
+	// StateConnected means that the client has subscribed and waits for prices
+	StateConnected
+	// StateDisconnected means that the stream has broken and the client waits before reconnecting
+	StateDisconnected
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	default:
+		return fmt.Sprintf("State(%d)", int32(s))
+	}
+}
+
+// Status describes the connection to the pricer. Stale is true if no price has arrived for the stale time
+type Status struct {
+	State      State
+	Since      time.Time // when the state has changed
+	LastTick   time.Time // zero if no price has arrived
+	Reconnects int64
+	Stale      bool
+}
+
+// Symbols tells which symbols the broker trades and when they change
+type Symbols interface {
+	TradableSymbols() []int32
+	SymbolEvents() <-chan *model.SymbolEvent
+}
+
+// Client receives prices from the pricer and sends them to out
+type Client struct {
+	client     protocol.PricesClient
+	symbols    Symbols
+	out        chan<- *model.Price
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	staleAfter time.Duration
+
+	muStream sync.Mutex
+	stream   protocol.Prices_SubscribeClient // nil while disconnected
+
+	muStatus sync.RWMutex
+	status   Status
+	started  time.Time
+}
+
+// NewClient is constructor. After the stream breaks the client waits minBackoff and doubles the wait up to
+// maxBackoff until a price arrives. It alerts if no price has arrived for staleAfter
+func NewClient(client protocol.PricesClient, symbols Symbols, out chan<- *model.Price,
+	minBackoff, maxBackoff, staleAfter time.Duration) (*Client, error) {
+	if minBackoff <= 0 || maxBackoff < minBackoff {
+		return nil, errors.New("backoff must be positive and the max backoff mustn't be less than the min backoff")
+	}
+	if staleAfter <= 0 {
+		return nil, errors.New("stale time must be positive")
+	}
+	now := time.Now()
+	return &Client{
+		client:     client,
+		symbols:    symbols,
+		out:        out,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		staleAfter: staleAfter,
+		status:     Status{State: StateConnecting, Since: now},
+		started:    now,
+	}, nil
+}
+
+// Run receives prices and reconnects until ctx is done
+func (c *Client) Run(ctx context.Context) {
+	go c.forwardSymbols(ctx)
+	go c.watchTicks(ctx)
+
+	backoff := c.minBackoff
+	for {
+		c.setState(StateConnecting)
+		received, err := c.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			backoff = c.minBackoff
+		}
+		c.setState(StateDisconnected)
+		log.Errorf("stream of prices has broken, reconnect in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		c.muStatus.Lock()
+		c.status.Reconnects++
+		c.muStatus.Unlock()
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// Status returns the state of the connection
+func (c *Client) Status() *Status {
+	c.muStatus.RLock()
+	defer c.muStatus.RUnlock()
+	status := c.status
+	return &status
+}
+
+// receive opens the stream, subscribes to the tradable symbols and sends prices to out until the stream breaks.
+// Returns true if at least one price has arrived
+func (c *Client) receive(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.Subscribe(ctx)
+	if err != nil {
+		return false, err
+	}
+	// the symbols are read under muStream, so an event which changes them is sent after the subscription
+	c.muStream.Lock()
+	err = stream.Send(&protocol.SubscribeRequest{
+		Action:  actionSubscribe,
+		PriceId: c.symbols.TradableSymbols(),
+	})
+	if err == nil {
+		c.stream = stream
+	}
+	c.muStream.Unlock()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		c.muStream.Lock()
+		c.stream = nil
+		c.muStream.Unlock()
+	}()
+	c.setState(StateConnected)
+
+	received := false
+	for {
+		price, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		c.tick()
+		p, err := toPrice(price)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		select {
+		case c.out <- p:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
+
+// forwardSymbols updates the subscription when symbols are added or disabled. While the client is disconnected
+// events are skipped, the next subscription takes the current symbols
+func (c *Client) forwardSymbols(ctx context.Context) {
+	events := c.symbols.SymbolEvents()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			action := int32(actionUnsubscribe)
+			if event.Tradable {
+				action = actionSubscribe
+			}
+			c.muStream.Lock()
+			if c.stream != nil {
+				err := c.stream.Send(&protocol.SubscribeRequest{
+					Action:  action,
+					PriceId: []int32{event.SymbolID},
+				})
+				if err != nil {
+					log.Errorf("subscription to prices of symbol %d isn't updated: %v", event.SymbolID, err)
+				}
+			}
+			c.muStream.Unlock()
+		}
+	}
+}
+
+// watchTicks alerts once no price has arrived for the stale time while the broker trades any symbol
+func (c *Client) watchTicks(ctx context.Context) {
+	ticker := time.NewTicker(c.staleAfter / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.muStatus.Lock()
+			last := c.status.LastTick
+			if last.IsZero() {
+				last = c.started
+			}
+			alert := !c.status.Stale && now.Sub(last) >= c.staleAfter && len(c.symbols.TradableSymbols()) > 0
+			if alert {
+				c.status.Stale = true
+			}
+			state := c.status.State
+			c.muStatus.Unlock()
+			if alert {
+				log.Errorf("no prices from the pricer since %s, the connection is %s", last.Format(time.RFC3339), state)
+			}
+		}
+	}
+}
+
+// tick marks that a price has arrived
+func (c *Client) tick() {
+	c.muStatus.Lock()
+	defer c.muStatus.Unlock()
+	c.status.LastTick = time.Now()
+	if c.status.Stale {
+		c.status.Stale = false
+		log.Info("prices from the pricer have resumed")
+	}
+}
+
+func (c *Client) setState(state State) {
+	c.muStatus.Lock()
+	defer c.muStatus.Unlock()
+	if c.status.State == state {
+		return
+	}
+	c.status.State = state
+	c.status.Since = time.Now()
+	log.Infof("connection to the pricer is %s", state)
+}
+
+// toPrice converts the price of the pricer into exact amounts
+func toPrice(price *protocol.Price) (*model.Price, error) {
+	bid, err := money.FromFloat32(price.Bid)
+	if err != nil {
+		return nil, err
+	}
+	ask, err := money.FromFloat32(price.Ask)
+	if err != nil {
+		return nil, err
+	}
+	p := &model.Price{
+		ID:  price.PriceId,
+		Bid: bid,
+		Ask: ask,
+	}
+	if price.Update != nil {
+		p.Time = price.Update.Seconds
+	}
+	return p, nil
+}
@@ -0,0 +1,184 @@
+package pricer
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	protocol "github.com/chucky-1/pricer/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSymbols trades the symbols and sends events which the test puts
+type testSymbols struct {
+	mu      sync.Mutex
+	symbols []int32
+	events  chan *model.SymbolEvent
+}
+
+func (s *testSymbols) TradableSymbols() []int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int32(nil), s.symbols...)
+}
+
+func (s *testSymbols) SymbolEvents() <-chan *model.SymbolEvent {
+	return s.events
+}
+
+// testStream records requests and returns prices from its chan. Recv fails when the chan is closed
+type testStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	prices chan *protocol.Price
+
+	mu       sync.Mutex
+	requests []*protocol.SubscribeRequest
+}
+
+func (s *testStream) Send(r *protocol.SubscribeRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	return nil
+}
+
+func (s *testStream) Recv() (*protocol.Price, error) {
+	select {
+	case price, ok := <-s.prices:
+		if !ok {
+			return nil, errors.New("stream is broken")
+		}
+		return price, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *testStream) sent() []*protocol.SubscribeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*protocol.SubscribeRequest(nil), s.requests...)
+}
+
+// testPrices fails the first Subscribe calls and then opens streams
+type testPrices struct {
+	fails int
+
+	mu      sync.Mutex
+	calls   int
+	streams []*testStream
+}
+
+func (p *testPrices) Subscribe(ctx context.Context, opts ...grpc.CallOption) (protocol.Prices_SubscribeClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.calls <= p.fails {
+		return nil, errors.New("pricer is unavailable")
+	}
+	stream := &testStream{ctx: ctx, prices: make(chan *protocol.Price)}
+	p.streams = append(p.streams, stream)
+	return stream, nil
+}
+
+func (p *testPrices) stream(i int) *testStream {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i >= len(p.streams) {
+		return nil
+	}
+	return p.streams[i]
+}
+
+func TestClient_reconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	symbols := &testSymbols{symbols: []int32{1, 2}, events: make(chan *model.SymbolEvent)}
+	prices := &testPrices{fails: 2}
+	out := make(chan *model.Price)
+	c, err := NewClient(prices, symbols, out, time.Millisecond, 4*time.Millisecond, time.Minute)
+	require.NoError(t, err)
+	go c.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return c.Status().State == StateConnected
+	}, time.Second, time.Millisecond)
+	first := prices.stream(0)
+	require.NotNil(t, first)
+	assert.Equal(t, int64(2), c.Status().Reconnects, "failed subscriptions are retried")
+	assert.Equal(t, []*protocol.SubscribeRequest{{Action: actionSubscribe, PriceId: []int32{1, 2}}}, first.sent())
+
+	first.prices <- &protocol.Price{PriceId: 1, Bid: 10, Ask: 11, Update: &protocol.Timestamp{Seconds: 5}}
+	price := <-out
+	assert.Equal(t, &model.Price{ID: 1, Bid: money.New(10), Ask: money.New(11), Time: 5}, price)
+	assert.False(t, c.Status().LastTick.IsZero())
+
+	symbols.mu.Lock()
+	symbols.symbols = []int32{1, 2, 3}
+	symbols.mu.Unlock()
+	symbols.events <- &model.SymbolEvent{SymbolID: 3, Tradable: true}
+	require.Eventually(t, func() bool {
+		return len(first.sent()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, &protocol.SubscribeRequest{Action: actionSubscribe, PriceId: []int32{3}}, first.sent()[1])
+
+	close(first.prices)
+	require.Eventually(t, func() bool {
+		return prices.stream(1) != nil && len(prices.stream(1).sent()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []*protocol.SubscribeRequest{{Action: actionSubscribe, PriceId: []int32{1, 2, 3}}}, prices.stream(1).sent(),
+		"the new stream is subscribed to the current symbols")
+	require.Eventually(t, func() bool {
+		return c.Status().State == StateConnected
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int64(3), c.Status().Reconnects)
+}
+
+func TestClient_stale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	symbols := &testSymbols{symbols: []int32{1}, events: make(chan *model.SymbolEvent)}
+	prices := &testPrices{}
+	out := make(chan *model.Price, 1)
+	c, err := NewClient(prices, symbols, out, time.Millisecond, time.Millisecond, 20*time.Millisecond)
+	require.NoError(t, err)
+	go c.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return c.Status().Stale
+	}, time.Second, time.Millisecond, "no price has arrived since the start")
+	assert.Equal(t, StateConnected, c.Status().State, "a connected stream may be stale")
+
+	prices.stream(0).prices <- &protocol.Price{PriceId: 1, Bid: 10, Ask: 11}
+	require.Eventually(t, func() bool {
+		return !c.Status().Stale
+	}, time.Second, time.Millisecond, "alert is cleared when prices resume")
+}
+
+func TestNewClient(t *testing.T) {
+	testTable := []struct {
+		name       string
+		minBackoff time.Duration
+		maxBackoff time.Duration
+		staleAfter time.Duration
+		err        bool
+	}{
+		{name: "ok", minBackoff: time.Second, maxBackoff: time.Minute, staleAfter: time.Minute},
+		{name: "zero backoff", maxBackoff: time.Minute, staleAfter: time.Minute, err: true},
+		{name: "max less than min", minBackoff: time.Minute, maxBackoff: time.Second, staleAfter: time.Minute, err: true},
+		{name: "zero stale time", minBackoff: time.Second, maxBackoff: time.Minute, err: true},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(&testPrices{}, &testSymbols{}, nil, tt.minBackoff, tt.maxBackoff, tt.staleAfter)
+			assert.Equal(t, tt.err, err != nil)
+		})
+	}
+}
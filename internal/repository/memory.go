@@ -0,0 +1,640 @@
+package repository
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	"github.com/chucky-1/broker/internal/request"
+
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory implements Repository in memory. It is used by tests and for running the broker without a database
+type Memory struct {
+	st   *memoryState
+	undo *undoLog // nil outside of a transaction
+}
+
+// memoryState is shared by Memory and the transactions started from it
+type memoryState struct {
+	mu sync.RWMutex // a transaction holds it until it finishes, so nobody sees its changes before commit
+
+	users     map[int32]model.User // map[user.ID]user
+	logins    map[string]int32     // map[user.Login]user.ID
+	positions map[int32]model.Position
+	orders    map[int32]memoryOrder
+	ledger    []model.LedgerEntry
+	symbols   map[int32]model.Symbol
+	margin    []model.MarginEvent
+
+	userSeq        int32
+	positionSeq    int32
+	orderSeq       int32
+	entrySeq       int32
+	transactionSeq int32
+	symbolSeq      int32
+}
+
+// memoryOrder is an order with the fields which are set when it fills or is rejected
+type memoryOrder struct {
+	order        model.Order
+	filled       bool
+	positionID   int32
+	rejected     bool
+	rejectReason string
+}
+
+// undoLog reverts the changes of a transaction which rolls back, the last change first.
+// Sequences aren't reverted like in postgres
+type undoLog []func()
+
+// NewMemory is constructor
+func NewMemory() *Memory {
+	return &Memory{st: &memoryState{
+		users:     make(map[int32]model.User),
+		logins:    make(map[string]int32),
+		positions: make(map[int32]model.Position),
+		orders:    make(map[int32]memoryOrder),
+		symbols:   make(map[int32]model.Symbol),
+	}}
+}
+
+// InTx runs fn with the state locked and reverts the changes of fn if it returns an error
+func (m *Memory) InTx(ctx context.Context, fn func(rep Repository) error) error {
+	if m.undo != nil {
+		return fn(m)
+	}
+	m.st.mu.Lock()
+	defer m.st.mu.Unlock()
+
+	undo := &undoLog{}
+	err := fn(&Memory{st: m.st, undo: undo})
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		undo.revert()
+		return err
+	}
+	return nil
+}
+
+// SignUp creates new user with zero balance
+func (m *Memory) SignUp(ctx context.Context, login, passwordHash string, leverage int32) (*model.User, error) {
+	var user model.User
+	err := m.write(func() error {
+		if _, ok := m.st.logins[login]; ok {
+			return fmt.Errorf("login %s is already taken", login)
+		}
+		m.st.userSeq++
+		user = model.User{ID: m.st.userSeq, Login: login, PasswordHash: passwordHash, Leverage: leverage}
+		m.setUser(user)
+		m.setLogin(login, user.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SignIn gets user with the login
+func (m *Memory) SignIn(ctx context.Context, login string) (*model.User, error) {
+	defer m.rlock()()
+	id, ok := m.st.logins[login]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	user := m.st.users[id]
+	return &user, nil
+}
+
+// OpenPosition opens position. Returns id of position
+func (m *Memory) OpenPosition(ctx context.Context, position *request.OpenPositionRepository, t time.Time) (int32, error) {
+	var id int32
+	err := m.write(func() error {
+		m.st.positionSeq++
+		id = m.st.positionSeq
+		m.setPosition(model.Position{
+			ID:           id,
+			UserID:       position.UserID,
+			SymbolID:     position.SymbolID,
+			SymbolTitle:  position.SymbolTitle,
+			Count:        position.Count,
+			PriceOpen:    position.PriceOpen,
+			TimeOpen:     t,
+			StopLoss:     position.StopLoss,
+			TakeProfit:   position.TakeProfit,
+			TrailingStop: position.TrailingStop,
+			IsBuy:        position.IsBuy,
+			Margin:       position.Margin,
+		})
+		return nil
+	})
+	return id, err
+}
+
+// ClosePosition closes position. A position which has been closed already isn't closed again, nor is
+// a position whose count has changed since the pnl was counted
+func (m *Memory) ClosePosition(ctx context.Context, position *request.ClosePosition) error {
+	return m.write(func() error {
+		p, ok := m.st.positions[position.ID]
+		if !ok || !p.TimeClose.IsZero() || p.Count != position.Count {
+			return errors.New("position didn't close")
+		}
+		p.PriceClose = position.PriceClose
+		p.TimeClose = time.Now()
+		p.Pnl = position.Pnl
+		p.CloseReason = position.Reason
+		m.setPosition(p)
+		return nil
+	})
+}
+
+// ClosePartOfPosition decreases count and margin of the open position and stores the closed part as a closed
+// position. Returns id of the closed part
+func (m *Memory) ClosePartOfPosition(ctx context.Context, position *request.ClosePosition) (int32, error) {
+	var id int32
+	err := m.write(func() error {
+		parent, ok := m.st.positions[position.ID]
+		if !ok || !parent.TimeClose.IsZero() || parent.Count <= position.Count {
+			return errors.New("part of position didn't close")
+		}
+		parent.Count -= position.Count
+		parent.Margin -= position.Margin
+		m.setPosition(parent)
+
+		m.st.positionSeq++
+		id = m.st.positionSeq
+		part := parent
+		part.ID = id
+		part.Count = position.Count
+		part.Margin = position.Margin
+		part.PriceClose = position.PriceClose
+		part.TimeClose = time.Now()
+		part.Pnl = position.Pnl
+		part.CloseReason = position.Reason
+		part.ParentID = parent.ID
+		m.setPosition(part)
+		return nil
+	})
+	return id, err
+}
+
+// ChangeStopLoss changes stop loss of the position
+func (m *Memory) ChangeStopLoss(ctx context.Context, positionID int32, stopLoss money.Amount) error {
+	return m.write(func() error {
+		p, ok := m.st.positions[positionID]
+		if !ok {
+			return errors.New("stop loss didn't change")
+		}
+		p.StopLoss = stopLoss
+		m.setPosition(p)
+		return nil
+	})
+}
+
+// ModifyPosition changes stop loss and take profit of the open position
+func (m *Memory) ModifyPosition(ctx context.Context, position *request.ModifyPosition) error {
+	return m.write(func() error {
+		p, ok := m.st.positions[position.PositionID]
+		if !ok || !p.TimeClose.IsZero() {
+			return errors.New("position didn't modify")
+		}
+		p.StopLoss = position.StopLoss
+		p.TakeProfit = position.TakeProfit
+		m.setPosition(p)
+		return nil
+	})
+}
+
+// GetPosition returns a position
+func (m *Memory) GetPosition(ctx context.Context, positionID int32) (*model.Position, error) {
+	defer m.rlock()()
+	p, ok := m.st.positions[positionID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &p, nil
+}
+
+// GetOpenPositions returns all open positions for the certain user
+func (m *Memory) GetOpenPositions(userID int32) (map[int32]*model.Position, error) {
+	return m.openPositions(func(p *model.Position) bool {
+		return p.UserID == userID
+	}), nil
+}
+
+// GetClosedPositions returns a page of closed positions of the certain user from the last closed
+func (m *Memory) GetClosedPositions(ctx context.Context, list *request.ListPositions) ([]*model.Position, error) {
+	unlock := m.rlock()
+	var positions []*model.Position
+	for _, p := range m.st.positions {
+		if p.UserID == list.UserID && !p.TimeClose.IsZero() {
+			p := p
+			positions = append(positions, &p)
+		}
+	}
+	cursor, ok := m.st.positions[list.Cursor]
+	unlock()
+	sort.Slice(positions, func(i, j int) bool {
+		return closedAfter(positions[i], positions[j])
+	})
+	if list.Cursor != 0 {
+		if !ok {
+			return nil, nil
+		}
+		i := sort.Search(len(positions), func(i int) bool {
+			return closedAfter(&cursor, positions[i])
+		})
+		positions = positions[i:]
+	}
+	if int32(len(positions)) > list.Limit {
+		positions = positions[:list.Limit]
+	}
+	return positions, nil
+}
+
+// GetAllOpenPositions returns all open positions
+func (m *Memory) GetAllOpenPositions() (map[int32]*model.Position, error) {
+	return m.openPositions(func(*model.Position) bool {
+		return true
+	}), nil
+}
+
+// GetAllUsers returns all users
+func (m *Memory) GetAllUsers() (map[int32]*model.User, error) {
+	defer m.rlock()()
+	users := make(map[int32]*model.User, len(m.st.users))
+	for id, u := range m.st.users {
+		u := u
+		users[id] = &u
+	}
+	return users, nil
+}
+
+// SetLeverage changes leverage of the user. Positions which are open keep their margin
+func (m *Memory) SetLeverage(ctx context.Context, userID, leverage int32) error {
+	return m.write(func() error {
+		u, ok := m.st.users[userID]
+		if !ok {
+			return ErrNotFound
+		}
+		u.Leverage = leverage
+		m.setUser(u)
+		return nil
+	})
+}
+
+// AddMarginEvent stores a step of the margin control
+func (m *Memory) AddMarginEvent(ctx context.Context, event *model.MarginEvent) error {
+	return m.write(func() error {
+		m.appendMarginEvent(*event)
+		return nil
+	})
+}
+
+// ChangeBalance changes user's balance and books the movement to the ledger
+func (m *Memory) ChangeBalance(ctx context.Context, change *request.ChangeBalance) error {
+	return m.write(func() error {
+		u, ok := m.st.users[change.UserID]
+		if !ok {
+			return errors.New("balance didn't change")
+		}
+		u.Balance += change.Sum
+		m.setUser(u)
+
+		m.st.transactionSeq++
+		t := time.Now()
+		for _, side := range []struct {
+			account string
+			amount  money.Amount
+		}{
+			{account: model.AccountUser, amount: change.Sum},
+			{account: change.Type.ContraAccount(), amount: -change.Sum},
+		} {
+			m.st.entrySeq++
+			m.appendLedgerEntry(model.LedgerEntry{
+				ID:            m.st.entrySeq,
+				TransactionID: m.st.transactionSeq,
+				UserID:        u.ID,
+				Account:       side.account,
+				Type:          change.Type,
+				Amount:        side.amount,
+				PositionID:    change.PositionID,
+				OrderID:       change.OrderID,
+				Time:          t,
+			})
+		}
+		return nil
+	})
+}
+
+// RebuildBalances sets balances of users to the sums of their ledger accounts. Returns count of users whose
+// balance didn't match the ledger
+func (m *Memory) RebuildBalances(ctx context.Context) (int64, error) {
+	var count int64
+	err := m.write(func() error {
+		balances := make(map[int32]money.Amount, len(m.st.users))
+		for _, e := range m.st.ledger {
+			if e.Account == model.AccountUser {
+				balances[e.UserID] += e.Amount
+			}
+		}
+		for id, u := range m.st.users {
+			if u.Balance != balances[id] {
+				u.Balance = balances[id]
+				m.setUser(u)
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// GetAccountHistory returns entries of the user's account from the newest which match the filters
+func (m *Memory) GetAccountHistory(ctx context.Context, history *request.AccountHistory) ([]*model.AccountEntry, error) {
+	types := make(map[model.EntryType]bool, len(history.Types))
+	for _, t := range history.Types {
+		types[t] = true
+	}
+	defer m.rlock()()
+	var entries []*model.AccountEntry
+	for i := len(m.st.ledger) - 1; i >= 0 && int32(len(entries)) < history.Limit; i-- {
+		e := m.st.ledger[i]
+		if e.UserID != history.UserID || e.Account != model.AccountUser ||
+			(history.Cursor != 0 && e.ID >= history.Cursor) ||
+			(!history.From.IsZero() && e.Time.Before(history.From)) ||
+			(!history.To.IsZero() && !e.Time.Before(history.To)) ||
+			(len(types) != 0 && !types[e.Type]) {
+			continue
+		}
+		entry := model.AccountEntry{
+			ID:         e.ID,
+			Type:       e.Type,
+			Amount:     e.Amount,
+			Time:       e.Time,
+			PositionID: e.PositionID,
+			OrderID:    e.OrderID,
+		}
+		if p, ok := m.st.positions[e.PositionID]; ok {
+			entry.SymbolID = p.SymbolID
+			entry.SymbolTitle = p.SymbolTitle
+			if e.Type == model.EntryPositionClose {
+				entry.Pnl = p.Pnl
+			}
+		}
+		if history.SymbolID != 0 && entry.SymbolID != history.SymbolID {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+// GetSymbols returns all symbols
+func (m *Memory) GetSymbols(ctx context.Context) (map[int32]*model.Symbol, error) {
+	defer m.rlock()()
+	symbols := make(map[int32]*model.Symbol, len(m.st.symbols))
+	for id, symbol := range m.st.symbols {
+		symbol := symbol
+		symbols[id] = &symbol
+	}
+	return symbols, nil
+}
+
+// AddSymbol stores new tradable symbol. Returns id of symbol
+func (m *Memory) AddSymbol(ctx context.Context, symbol *request.AddSymbol) (int32, error) {
+	var id int32
+	err := m.write(func() error {
+		for _, s := range m.st.symbols {
+			if s.Ticker == symbol.Ticker {
+				return fmt.Errorf("ticker %s is already taken", symbol.Ticker)
+			}
+		}
+		m.st.symbolSeq++
+		id = m.st.symbolSeq
+		m.setSymbol(model.Symbol{
+			ID:       id,
+			Ticker:   symbol.Ticker,
+			Title:    symbol.Title,
+			Tradable: true,
+			LotSize:  symbol.LotSize,
+			TickSize: symbol.TickSize,
+			Currency: symbol.Currency,
+		})
+		return nil
+	})
+	return id, err
+}
+
+// SetSymbolTradable allows or forbids trading the symbol
+func (m *Memory) SetSymbolTradable(ctx context.Context, symbolID int32, tradable bool) error {
+	return m.write(func() error {
+		symbol, ok := m.st.symbols[symbolID]
+		if !ok {
+			return ErrNotFound
+		}
+		symbol.Tradable = tradable
+		m.setSymbol(symbol)
+		return nil
+	})
+}
+
+// PlaceOrder stores pending order. Returns id of order
+func (m *Memory) PlaceOrder(ctx context.Context, order *request.PlaceOrder, t time.Time) (int32, error) {
+	var id int32
+	err := m.write(func() error {
+		m.st.orderSeq++
+		id = m.st.orderSeq
+		m.setOrder(memoryOrder{order: model.Order{
+			ID:         id,
+			UserID:     order.UserID,
+			SymbolID:   order.SymbolID,
+			Type:       order.Type,
+			Count:      order.Count,
+			Price:      order.Price,
+			StopLoss:   order.StopLoss,
+			TakeProfit: order.TakeProfit,
+			IsBuy:      order.IsBuy,
+			TimeCreate: t,
+		}})
+		return nil
+	})
+	return id, err
+}
+
+// FillOrder marks order as filled by the position
+func (m *Memory) FillOrder(ctx context.Context, orderID, positionID int32) error {
+	return m.write(func() error {
+		o, ok := m.st.orders[orderID]
+		if !ok || o.filled || o.rejected {
+			return errors.New("order didn't fill")
+		}
+		o.filled = true
+		o.positionID = positionID
+		m.setOrder(o)
+		return nil
+	})
+}
+
+// RejectOrder marks pending order as rejected for the reason, it isn't pending anymore
+func (m *Memory) RejectOrder(ctx context.Context, orderID int32, reason string) error {
+	return m.write(func() error {
+		o, ok := m.st.orders[orderID]
+		if !ok || o.filled || o.rejected {
+			return errors.New("order didn't reject")
+		}
+		o.rejected = true
+		o.rejectReason = reason
+		m.setOrder(o)
+		return nil
+	})
+}
+
+// GetPendingOrders returns all orders of the certain user which have neither filled nor been rejected
+func (m *Memory) GetPendingOrders(userID int32) (map[int32]*model.Order, error) {
+	defer m.rlock()()
+	orders := make(map[int32]*model.Order)
+	for id, o := range m.st.orders {
+		if o.order.UserID == userID && !o.filled && !o.rejected {
+			order := o.order
+			orders[id] = &order
+		}
+	}
+	return orders, nil
+}
+
+// write changes the state. Outside of a transaction it waits for the running transaction to finish
+func (m *Memory) write(fn func() error) error {
+	if m.undo == nil {
+		m.st.mu.Lock()
+		defer m.st.mu.Unlock()
+	}
+	return fn()
+}
+
+// rlock locks the state for reading and returns the unlock. A transaction holds the lock already
+func (m *Memory) rlock() func() {
+	if m.undo != nil {
+		return func() {}
+	}
+	m.st.mu.RLock()
+	return m.st.mu.RUnlock
+}
+
+// logUndo remembers how to revert a change if it is made in a transaction
+func (m *Memory) logUndo(fn func()) {
+	if m.undo != nil {
+		*m.undo = append(*m.undo, fn)
+	}
+}
+
+func (m *Memory) setUser(u model.User) {
+	old, ok := m.st.users[u.ID]
+	m.logUndo(func() {
+		if ok {
+			m.st.users[u.ID] = old
+		} else {
+			delete(m.st.users, u.ID)
+		}
+	})
+	m.st.users[u.ID] = u
+}
+
+func (m *Memory) setLogin(login string, userID int32) {
+	old, ok := m.st.logins[login]
+	m.logUndo(func() {
+		if ok {
+			m.st.logins[login] = old
+		} else {
+			delete(m.st.logins, login)
+		}
+	})
+	m.st.logins[login] = userID
+}
+
+func (m *Memory) setPosition(p model.Position) {
+	old, ok := m.st.positions[p.ID]
+	m.logUndo(func() {
+		if ok {
+			m.st.positions[p.ID] = old
+		} else {
+			delete(m.st.positions, p.ID)
+		}
+	})
+	m.st.positions[p.ID] = p
+}
+
+func (m *Memory) setOrder(o memoryOrder) {
+	old, ok := m.st.orders[o.order.ID]
+	m.logUndo(func() {
+		if ok {
+			m.st.orders[o.order.ID] = old
+		} else {
+			delete(m.st.orders, o.order.ID)
+		}
+	})
+	m.st.orders[o.order.ID] = o
+}
+
+func (m *Memory) setSymbol(symbol model.Symbol) {
+	old, ok := m.st.symbols[symbol.ID]
+	m.logUndo(func() {
+		if ok {
+			m.st.symbols[symbol.ID] = old
+		} else {
+			delete(m.st.symbols, symbol.ID)
+		}
+	})
+	m.st.symbols[symbol.ID] = symbol
+}
+
+func (m *Memory) appendLedgerEntry(e model.LedgerEntry) {
+	n := len(m.st.ledger)
+	m.logUndo(func() {
+		m.st.ledger = m.st.ledger[:n]
+	})
+	m.st.ledger = append(m.st.ledger, e)
+}
+
+func (m *Memory) appendMarginEvent(event model.MarginEvent) {
+	n := len(m.st.margin)
+	m.logUndo(func() {
+		m.st.margin = m.st.margin[:n]
+	})
+	m.st.margin = append(m.st.margin, event)
+}
+
+// openPositions returns copies of the open positions which match
+func (m *Memory) openPositions(match func(p *model.Position) bool) map[int32]*model.Position {
+	defer m.rlock()()
+	positions := make(map[int32]*model.Position)
+	for id, p := range m.st.positions {
+		p := p
+		if p.TimeClose.IsZero() && match(&p) {
+			positions[id] = &p
+		}
+	}
+	return positions
+}
+
+// closedAfter returns true if a was closed after b. Positions closed at the same time are ordered by id
+func closedAfter(a, b *model.Position) bool {
+	if a.TimeClose.Equal(b.TimeClose) {
+		return a.ID > b.ID
+	}
+	return a.TimeClose.After(b.TimeClose)
+}
+
+// revert undoes the changes from the last one
+func (l undoLog) revert() {
+	for i := len(l) - 1; i >= 0; i-- {
+		l[i]()
+	}
+}
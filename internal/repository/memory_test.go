@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	"github.com/chucky-1/broker/internal/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemory_SignUp(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	u, err := m.SignUp(ctx, "trader", "hash", 1)
+	require.NoError(t, err)
+	_, err = m.SignUp(ctx, "trader", "other", 1)
+	assert.EqualError(t, err, "login trader is already taken")
+
+	got, err := m.SignIn(ctx, "trader")
+	require.NoError(t, err)
+	assert.Equal(t, u, got)
+	_, err = m.SignIn(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// newTestUser signs up a user with the deposit
+func newTestUser(t *testing.T, ctx context.Context, m *Memory, deposit money.Amount) *model.User {
+	u, err := m.SignUp(ctx, "trader", "hash", 1)
+	require.NoError(t, err)
+	err = m.ChangeBalance(ctx, &request.ChangeBalance{UserID: u.ID, Sum: deposit, Type: model.EntryDeposit})
+	require.NoError(t, err)
+	return u
+}
+
+func TestMemory_ClosePosition(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	u := newTestUser(t, ctx, m, money.New(100))
+	id, err := m.OpenPosition(ctx, &request.OpenPositionRepository{
+		UserID:    u.ID,
+		SymbolID:  1,
+		Count:     10,
+		PriceOpen: money.New(5),
+		IsBuy:     true,
+		Margin:    money.New(50),
+	}, time.Now())
+	require.NoError(t, err)
+	_, err = m.ClosePartOfPosition(ctx, &request.ClosePosition{ID: id, Count: 4, PriceClose: money.New(6),
+		Pnl: money.New(4), Margin: money.New(20), Reason: model.CloseManual})
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name       string
+		count      int32
+		expectErr  bool
+		expectOpen int
+	}{
+		{
+			name:       "Failed if count has changed since the pnl was counted",
+			count:      10,
+			expectErr:  true,
+			expectOpen: 1,
+		},
+		{
+			name:       "OK if count is the count of the position",
+			count:      6,
+			expectOpen: 0,
+		},
+		{
+			name:       "Failed if position is already closed",
+			count:      6,
+			expectErr:  true,
+			expectOpen: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := m.ClosePosition(ctx, &request.ClosePosition{
+				ID:         id,
+				Count:      testCase.count,
+				PriceClose: money.New(6),
+				Pnl:        money.New(int64(testCase.count)),
+				Margin:     money.New(5 * int64(testCase.count)),
+				Reason:     model.CloseStopLoss,
+			})
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			open, _ := m.GetOpenPositions(u.ID)
+			assert.Len(t, open, testCase.expectOpen)
+		})
+	}
+}
+
+func TestMemory_ClosePartOfPosition(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	u := newTestUser(t, ctx, m, money.New(100))
+	id, err := m.OpenPosition(ctx, &request.OpenPositionRepository{
+		UserID:    u.ID,
+		SymbolID:  1,
+		Count:     10,
+		PriceOpen: money.New(5),
+		IsBuy:     true,
+		Margin:    money.New(50),
+	}, time.Now())
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name         string
+		count        int32
+		expectErr    bool
+		expectOpen   int32
+		expectMargin money.Amount
+	}{
+		{
+			name:         "OK if part is less than count",
+			count:        4,
+			expectOpen:   6,
+			expectMargin: money.New(30),
+		},
+		{
+			name:         "Failed if part is whole position",
+			count:        6,
+			expectErr:    true,
+			expectOpen:   6,
+			expectMargin: money.New(30),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			partID, err := m.ClosePartOfPosition(ctx, &request.ClosePosition{
+				ID:         id,
+				Count:      testCase.count,
+				PriceClose: money.New(6),
+				Pnl:        money.New(int64(testCase.count)),
+				Margin:     money.New(5 * int64(testCase.count)),
+				Reason:     model.CloseManual,
+			})
+			open, _ := m.GetOpenPositions(u.ID)
+			assert.Equal(t, testCase.expectOpen, open[id].Count)
+			assert.Equal(t, testCase.expectMargin, open[id].Margin)
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			part, err := m.GetPosition(ctx, partID)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.count, part.Count)
+			assert.Equal(t, money.New(6), part.PriceClose)
+			assert.Equal(t, money.New(20), part.Margin)
+			assert.Equal(t, id, part.ParentID)
+			assert.Zero(t, open[id].ParentID)
+		})
+	}
+}
+
+func TestMemory_InTx(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	u := newTestUser(t, ctx, m, money.New(100))
+
+	errFailed := errors.New("failed")
+	testTable := []struct {
+		name          string
+		fail          bool
+		expectBalance money.Amount
+		expectOpen    int
+	}{
+		{
+			name:          "Rolls back if fn fails",
+			fail:          true,
+			expectBalance: money.New(100),
+			expectOpen:    0,
+		},
+		{
+			name:          "Commits if fn succeeds",
+			expectBalance: money.New(50),
+			expectOpen:    1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := m.InTx(ctx, func(rep Repository) error {
+				err := rep.ChangeBalance(ctx, &request.ChangeBalance{UserID: u.ID, Sum: -money.New(50), Type: model.EntryPositionOpen})
+				if err != nil {
+					return err
+				}
+				if _, err := rep.OpenPosition(ctx, &request.OpenPositionRepository{UserID: u.ID, Count: 1}, time.Now()); err != nil {
+					return err
+				}
+				if testCase.fail {
+					return errFailed
+				}
+				return nil
+			})
+			if testCase.fail {
+				assert.ErrorIs(t, err, errFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+			users, _ := m.GetAllUsers()
+			assert.Equal(t, testCase.expectBalance, users[u.ID].Balance)
+			open, _ := m.GetOpenPositions(u.ID)
+			assert.Len(t, open, testCase.expectOpen)
+		})
+	}
+
+	read := make(chan money.Amount)
+	err := m.InTx(ctx, func(rep Repository) error {
+		if _, err := rep.SignUp(ctx, "rolled back", "hash", 1); err != nil {
+			return err
+		}
+		err := rep.ChangeBalance(ctx, &request.ChangeBalance{UserID: u.ID, Sum: money.New(50), Type: model.EntryDeposit})
+		if err != nil {
+			return err
+		}
+		go func() {
+			users, _ := m.GetAllUsers()
+			read <- users[u.ID].Balance
+		}()
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, money.New(50), <-read, "readers don't see changes of the transaction")
+	assert.Len(t, m.st.ledger, 4)
+	_, err = m.SignUp(ctx, "rolled back", "hash", 1)
+	assert.NoError(t, err, "login of the rolled back user is free")
+}
+
+func TestMemory_ChangeBalance(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	u := newTestUser(t, ctx, m, money.New(100))
+
+	testTable := []struct {
+		name          string
+		change        *request.ChangeBalance
+		expectErr     bool
+		expectBalance money.Amount
+		expectEntries int
+	}{
+		{
+			name:          "OK if position opens",
+			change:        &request.ChangeBalance{UserID: u.ID, Sum: -money.New(30), Type: model.EntryPositionOpen, PositionID: 7},
+			expectBalance: money.New(70),
+			expectEntries: 4,
+		},
+		{
+			name:          "Failed if user doesn't exist",
+			change:        &request.ChangeBalance{UserID: u.ID + 1, Sum: money.New(1), Type: model.EntryAdjustment},
+			expectErr:     true,
+			expectBalance: money.New(70),
+			expectEntries: 4,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := m.ChangeBalance(ctx, testCase.change)
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			users, _ := m.GetAllUsers()
+			assert.Equal(t, testCase.expectBalance, users[u.ID].Balance)
+			require.Len(t, m.st.ledger, testCase.expectEntries)
+
+			var total money.Amount
+			for _, e := range m.st.ledger {
+				total += e.Amount
+			}
+			assert.Zero(t, total, "every transaction must sum to zero")
+		})
+	}
+	last := m.st.ledger[len(m.st.ledger)-1]
+	assert.Equal(t, model.EntryPositionOpen.ContraAccount(), last.Account)
+	assert.Equal(t, int32(7), last.PositionID)
+}
+
+func TestMemory_RebuildBalances(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	u := newTestUser(t, ctx, m, money.New(100))
+
+	count, err := m.RebuildBalances(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	broken := m.st.users[u.ID]
+	broken.Balance = money.New(1)
+	m.st.users[u.ID] = broken
+
+	count, err = m.RebuildBalances(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	users, _ := m.GetAllUsers()
+	assert.Equal(t, money.New(100), users[u.ID].Balance)
+}
@@ -3,54 +3,125 @@ package repository
 
 import (
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
 	"github.com/chucky-1/broker/internal/request"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 	log "github.com/sirupsen/logrus"
 
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
-// Repository works with postgres
-type Repository struct {
-	conn *pgx.Conn
+// ErrNotFound means that the row didn't find
+var ErrNotFound = errors.New("not found")
+
+// conn is implemented by both *pgxpool.Pool and pgx.Tx, so the same queries run inside and outside a transaction
+type conn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Repository stores users, positions and orders
+type Repository interface {
+	// InTx runs fn as a unit of work: every call fn makes through rep is committed together,
+	// or rolled back if fn returns an error
+	InTx(ctx context.Context, fn func(rep Repository) error) error
+	SignUp(ctx context.Context, login, passwordHash string, leverage int32) (*model.User, error)
+	SignIn(ctx context.Context, login string) (*model.User, error)
+	OpenPosition(ctx context.Context, position *request.OpenPositionRepository, t time.Time) (int32, error)
+	ClosePosition(ctx context.Context, position *request.ClosePosition) error
+	ClosePartOfPosition(ctx context.Context, position *request.ClosePosition) (int32, error)
+	ChangeStopLoss(ctx context.Context, positionID int32, stopLoss money.Amount) error
+	ModifyPosition(ctx context.Context, position *request.ModifyPosition) error
+	GetPosition(ctx context.Context, positionID int32) (*model.Position, error)
+	GetOpenPositions(userID int32) (map[int32]*model.Position, error)
+	GetClosedPositions(ctx context.Context, r *request.ListPositions) ([]*model.Position, error)
+	GetAllOpenPositions() (map[int32]*model.Position, error)
+	GetAllUsers() (map[int32]*model.User, error)
+	SetLeverage(ctx context.Context, userID, leverage int32) error
+	AddMarginEvent(ctx context.Context, event *model.MarginEvent) error
+	ChangeBalance(ctx context.Context, r *request.ChangeBalance) error
+	RebuildBalances(ctx context.Context) (int64, error)
+	GetAccountHistory(ctx context.Context, r *request.AccountHistory) ([]*model.AccountEntry, error)
+	GetSymbols(ctx context.Context) (map[int32]*model.Symbol, error)
+	AddSymbol(ctx context.Context, r *request.AddSymbol) (int32, error)
+	SetSymbolTradable(ctx context.Context, symbolID int32, tradable bool) error
+	PlaceOrder(ctx context.Context, order *request.PlaceOrder, t time.Time) (int32, error)
+	FillOrder(ctx context.Context, orderID, positionID int32) error
+	RejectOrder(ctx context.Context, orderID int32, reason string) error
+	GetPendingOrders(userID int32) (map[int32]*model.Order, error)
+}
+
+// Postgres implements Repository with postgres
+type Postgres struct {
+	conn conn
 }
 
-// NewRepository is constructor
-func NewRepository(conn *pgx.Conn) *Repository {
-	return &Repository{conn: conn}
+// NewPostgres is constructor
+func NewPostgres(pool *pgxpool.Pool) *Postgres {
+	return &Postgres{conn: pool}
+}
+
+// InTx runs fn in a postgres transaction
+func (r *Postgres) InTx(ctx context.Context, fn func(rep Repository) error) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error(err)
+		}
+	}()
+
+	if err = fn(&Postgres{conn: tx}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-// SignUp func creates new user
-func (r *Repository) SignUp(ctx context.Context, deposit float32) (*model.User, error) {
+// SignUp func creates new user with zero balance
+func (r *Postgres) SignUp(ctx context.Context, login, passwordHash string, leverage int32) (*model.User, error) {
 	var id int32
-	err := r.conn.QueryRow(ctx, "INSERT INTO users (id, balance) VALUES (nextval('users_sequence'), $1) RETURNING id",
-		deposit).Scan(&id)
+	err := r.conn.QueryRow(ctx, "INSERT INTO users (id, login, password_hash, balance, leverage) "+
+		"VALUES (nextval('users_sequence'), $1, $2, 0, $3) ON CONFLICT (login) DO NOTHING RETURNING id",
+		login, passwordHash, leverage).Scan(&id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("login %s is already taken", login)
+		}
 		return nil, err
 	}
-	return &model.User{ID: id, Balance: deposit}, nil
+	return &model.User{ID: id, Login: login, PasswordHash: passwordHash, Leverage: leverage}, nil
 }
 
-// SignIn gets user from database
-func (r *Repository) SignIn(id int32) (*model.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// SignIn gets user with the login from database
+func (r *Postgres) SignIn(ctx context.Context, login string) (*model.User, error) {
 	var user model.User
-	err := r.conn.QueryRow(ctx, "SELECT id, balance FROM users WHERE id = $1", id).Scan(&user.ID, &user.Balance)
+	err := r.conn.QueryRow(ctx, "SELECT id, login, password_hash, balance, leverage FROM users WHERE login = $1", login).
+		Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Balance, &user.Leverage)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 	return &user, nil
 }
 
 // OpenPosition func opens position. Returns id of position, error
-func (r *Repository) OpenPosition(ctx context.Context, position *request.OpenPositionRepository, t time.Time) (int32, error) {
+func (r *Postgres) OpenPosition(ctx context.Context, position *request.OpenPositionRepository, t time.Time) (int32, error) {
 	rows, err := r.conn.Query(ctx, "INSERT INTO positions (id, user_id, symbol_id, symbol_title, count, price_open, " +
-		"time_open, price_close, time_close, stop_loss, take_profit, is_buy) " +
-		"VALUES (nextval('positions_sequence'), $1, $2, $3, $4, $5, $6, NULL, NULL, $7, $8, $9) RETURNING id;",
-		position.UserID, position.SymbolID, position.SymbolTitle, position.Count, position.PriceOpen, t, position.StopLoss, position.TakeProfit, position.IsBuy)
+		"time_open, price_close, time_close, stop_loss, take_profit, trailing_stop, is_buy, margin) " +
+		"VALUES (nextval('positions_sequence'), $1, $2, $3, $4, $5, $6, NULL, NULL, $7, $8, $9, $10, $11) RETURNING id;",
+		position.UserID, position.SymbolID, position.SymbolTitle, position.Count, position.PriceOpen, t, position.StopLoss,
+		position.TakeProfit, position.TrailingStop, position.IsBuy, position.Margin)
 	if err != nil {
 		return 0, err
 	}
@@ -66,10 +137,12 @@ func (r *Repository) OpenPosition(ctx context.Context, position *request.OpenPos
 	return id, nil
 }
 
-// ClosePosition func closes position
-func (r *Repository) ClosePosition(ctx context.Context, position *request.ClosePosition) error {
-	commandTag, err := r.conn.Exec(ctx, "UPDATE positions SET price_close = $1, time_close = CURRENT_TIMESTAMP " +
-		"WHERE id = $2", position.PriceClose, position.ID)
+// ClosePosition func closes position. A position which has been closed already isn't closed again, nor is
+// a position whose count has changed since the pnl was counted
+func (r *Postgres) ClosePosition(ctx context.Context, position *request.ClosePosition) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE positions SET price_close = $1, time_close = CURRENT_TIMESTAMP, pnl = $2, " +
+		"close_reason = $3 WHERE id = $4 AND price_close IS NULL AND count = $5", position.PriceClose, position.Pnl,
+		position.Reason.String(), position.ID, position.Count)
 	if err != nil {
 		return err
 	}
@@ -79,28 +152,78 @@ func (r *Repository) ClosePosition(ctx context.Context, position *request.CloseP
 	return nil
 }
 
+// ClosePartOfPosition decreases count and margin of the open position and stores the closed part as a closed
+// position linked to the parent. Returns id of the closed part
+func (r *Postgres) ClosePartOfPosition(ctx context.Context, position *request.ClosePosition) (int32, error) {
+	var id int32
+	err := r.conn.QueryRow(ctx, "WITH parent AS (UPDATE positions SET count = count - $1, margin = margin - $6 "+
+		"WHERE id = $2 AND price_close IS NULL AND count > $1 RETURNING *) "+
+		"INSERT INTO positions (id, user_id, symbol_id, symbol_title, count, price_open, time_open, price_close, "+
+		"time_close, stop_loss, take_profit, trailing_stop, is_buy, parent_id, pnl, close_reason, margin) "+
+		"SELECT nextval('positions_sequence'), user_id, symbol_id, symbol_title, $1, price_open, time_open, $3, "+
+		"CURRENT_TIMESTAMP, stop_loss, take_profit, trailing_stop, is_buy, id, $4, $5, $6 FROM parent RETURNING id",
+		position.Count, position.ID, position.PriceClose, position.Pnl, position.Reason.String(), position.Margin).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, errors.New("part of position didn't close")
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// ChangeStopLoss changes stop loss of the position
+func (r *Postgres) ChangeStopLoss(ctx context.Context, positionID int32, stopLoss money.Amount) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE positions SET stop_loss = $1 WHERE id = $2", stopLoss, positionID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 1 {
+		return errors.New("stop loss didn't change")
+	}
+	return nil
+}
+
+// ModifyPosition changes stop loss and take profit of the open position
+func (r *Postgres) ModifyPosition(ctx context.Context, position *request.ModifyPosition) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE positions SET stop_loss = $1, take_profit = $2 "+
+		"WHERE id = $3 AND price_close IS NULL", position.StopLoss, position.TakeProfit, position.PositionID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 1 {
+		return errors.New("position didn't modify")
+	}
+	return nil
+}
+
 // GetPosition returns a position
-func (r *Repository) GetPosition(ctx context.Context, positionID int32) (*model.Position, error) {
+func (r *Postgres) GetPosition(ctx context.Context, positionID int32) (*model.Position, error) {
 	var position model.Position
 	err := r.conn.QueryRow(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, " +
-		"stop_loss, take_profit, is_buy FROM positions WHERE id = $1", positionID).Scan(&position.ID, &position.UserID,
-			&position.SymbolID, &position.SymbolTitle, &position.Count, &position.PriceOpen, &position.TimeOpen,
-			&position.StopLoss, &position.TakeProfit, &position.IsBuy)
+		"stop_loss, take_profit, trailing_stop, is_buy, margin, COALESCE(parent_id, 0) FROM positions WHERE id = $1",
+		positionID).Scan(&position.ID, &position.UserID, &position.SymbolID, &position.SymbolTitle, &position.Count,
+			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.TrailingStop,
+			&position.IsBuy, &position.Margin, &position.ParentID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 	return &position, nil
 }
 
 // GetOpenPositions returns all open positions for the certain user
-func (r *Repository) GetOpenPositions(userID int32) (map[int32]*model.Position, error) {
+func (r *Postgres) GetOpenPositions(userID int32) (map[int32]*model.Position, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	var count int32
 	r.conn.QueryRow(ctx, "SELECT count(*) FROM positions WHERE user_id = $1 AND price_close is NULL", userID).Scan(&count)
 
-	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, stop_loss, take_profit, is_buy " +
+	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, stop_loss, take_profit, " +
+		"trailing_stop, is_buy, margin " +
 		"FROM positions WHERE user_id = $1 AND price_close is NULL", userID)
 	if err != nil {
 		return nil, err
@@ -111,7 +234,8 @@ func (r *Repository) GetOpenPositions(userID int32) (map[int32]*model.Position,
 	for rows.Next() {
 		position := model.Position{}
 		err = rows.Scan(&position.ID, &position.UserID, &position.SymbolID, &position.SymbolTitle, &position.Count,
-			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.IsBuy)
+			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.TrailingStop,
+			&position.IsBuy, &position.Margin)
 		if err != nil {
 			return nil, err
 		}
@@ -120,11 +244,45 @@ func (r *Repository) GetOpenPositions(userID int32) (map[int32]*model.Position,
 	return positions, nil
 }
 
+// GetClosedPositions returns a page of closed positions of the certain user from the last closed with the reasons
+// of closing. Positions closed before the reason was stored are shown as closed manually
+func (r *Postgres) GetClosedPositions(ctx context.Context, list *request.ListPositions) ([]*model.Position, error) {
+	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, "+
+		"stop_loss, take_profit, trailing_stop, is_buy, margin, price_close, time_close, pnl, "+
+		"COALESCE(close_reason, 'manual') "+
+		"FROM positions WHERE user_id = $1 AND price_close IS NOT NULL "+
+		"AND ($2 = 0 OR (time_close, id) < (SELECT time_close, id FROM positions WHERE id = $2)) "+
+		"ORDER BY time_close DESC, id DESC LIMIT $3", list.UserID, list.Cursor, list.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []*model.Position
+	for rows.Next() {
+		var position model.Position
+		var reason string
+		err = rows.Scan(&position.ID, &position.UserID, &position.SymbolID, &position.SymbolTitle, &position.Count,
+			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.TrailingStop,
+			&position.IsBuy, &position.Margin, &position.PriceClose, &position.TimeClose, &position.Pnl, &reason)
+		if err != nil {
+			return nil, err
+		}
+		position.CloseReason, err = model.ParseCloseReason(reason)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, &position)
+	}
+	return positions, rows.Err()
+}
+
 // GetAllOpenPositions returns all open positions
-func (r *Repository) GetAllOpenPositions() (map[int32]*model.Position, error) {
+func (r *Postgres) GetAllOpenPositions() (map[int32]*model.Position, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, stop_loss, take_profit, is_buy " +
+	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, symbol_title, count, price_open, time_open, stop_loss, take_profit, " +
+		"trailing_stop, is_buy, margin " +
 		"FROM positions WHERE price_close is NULL")
 	if err != nil {
 		return nil, err
@@ -135,7 +293,8 @@ func (r *Repository) GetAllOpenPositions() (map[int32]*model.Position, error) {
 	for rows.Next() {
 		var position model.Position
 		err = rows.Scan(&position.ID, &position.UserID, &position.SymbolID, &position.SymbolTitle, &position.Count,
-			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.IsBuy)
+			&position.PriceOpen, &position.TimeOpen, &position.StopLoss, &position.TakeProfit, &position.TrailingStop,
+			&position.IsBuy, &position.Margin)
 		if err != nil {
 			return nil, err
 		}
@@ -145,10 +304,10 @@ func (r *Repository) GetAllOpenPositions() (map[int32]*model.Position, error) {
 }
 
 // GetAllUsers returns all users
-func (r *Repository) GetAllUsers() (map[int32]*model.User, error) {
+func (r *Postgres) GetAllUsers() (map[int32]*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	rows, err := r.conn.Query(ctx, "SELECT id, balance FROM users")
+	rows, err := r.conn.Query(ctx, "SELECT id, balance, leverage FROM users")
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +316,7 @@ func (r *Repository) GetAllUsers() (map[int32]*model.User, error) {
 	users := make(map[int32]*model.User)
 	for rows.Next() {
 		var user model.User
-		err = rows.Scan(&user.ID, &user.Balance)
+		err = rows.Scan(&user.ID, &user.Balance, &user.Leverage)
 		if err != nil {
 			return nil, err
 		}
@@ -166,25 +325,222 @@ func (r *Repository) GetAllUsers() (map[int32]*model.User, error) {
 	return users, nil
 }
 
-// GetUserIDByPositionID returns user's id by id of position
-func (r *Repository) GetUserIDByPositionID(ctx context.Context, positionID int32) (int32, error) {
-	var userID int32
-	err := r.conn.QueryRow(ctx, "SELECT user_id FROM positions WHERE id = $1", positionID).Scan(&userID)
+// SetLeverage changes leverage of the user. Positions which are open keep their margin
+func (r *Postgres) SetLeverage(ctx context.Context, userID, leverage int32) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE users SET leverage = $1 WHERE id = $2", leverage, userID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddMarginEvent stores a step of the margin control
+func (r *Postgres) AddMarginEvent(ctx context.Context, event *model.MarginEvent) error {
+	_, err := r.conn.Exec(ctx, "INSERT INTO margin_events (id, user_id, event_type, position_id, equity, used_margin, "+
+		"margin_level, time_create) VALUES (nextval('margin_events_sequence'), $1, $2, $3, $4, $5, $6, $7)",
+		event.UserID, event.Type.String(), nullID(event.PositionID), event.Equity, event.UsedMargin, event.MarginLevel,
+		event.Time)
+	return err
+}
+
+// ChangeBalance changes user's balance and books the movement to the ledger: to the user's account
+// and to the contra account of the entry type
+func (r *Postgres) ChangeBalance(ctx context.Context, change *request.ChangeBalance) error {
+	commandTag, err := r.conn.Exec(ctx, "WITH u AS (UPDATE users SET balance = balance + $2 WHERE id = $1 RETURNING id), "+
+		"t AS (SELECT nextval('ledger_transactions_sequence') AS id) "+
+		"INSERT INTO ledger_entries (id, transaction_id, user_id, account, entry_type, amount, position_id, order_id, "+
+		"time_create) SELECT nextval('ledger_entries_sequence'), t.id, u.id, a.account, $3, a.amount, $4, $5, "+
+		"CURRENT_TIMESTAMP FROM u, t, (VALUES ($6, $2::numeric), ($7, -$2::numeric)) AS a(account, amount)",
+		change.UserID, change.Sum, change.Type.String(), nullID(change.PositionID), nullID(change.OrderID),
+		model.AccountUser, change.Type.ContraAccount())
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 2 {
+		return errors.New("balance didn't change")
+	}
+	return nil
+}
+
+// RebuildBalances sets balances of users to the sums of their ledger accounts. Returns count of users whose
+// balance didn't match the ledger
+func (r *Postgres) RebuildBalances(ctx context.Context) (int64, error) {
+	commandTag, err := r.conn.Exec(ctx, "WITH ledger AS (SELECT u.id, COALESCE(sum(l.amount), 0) AS balance "+
+		"FROM users u LEFT JOIN ledger_entries l ON l.user_id = u.id AND l.account = $1 GROUP BY u.id) "+
+		"UPDATE users SET balance = ledger.balance FROM ledger "+
+		"WHERE users.id = ledger.id AND users.balance IS DISTINCT FROM ledger.balance", model.AccountUser)
+	if err != nil {
+		return 0, err
+	}
+	return commandTag.RowsAffected(), nil
+}
+
+// GetAccountHistory returns entries of the user's account from the newest which match the filters
+func (r *Postgres) GetAccountHistory(ctx context.Context, history *request.AccountHistory) ([]*model.AccountEntry, error) {
+	types := make([]string, 0, len(history.Types))
+	for _, t := range history.Types {
+		types = append(types, t.String())
+	}
+	rows, err := r.conn.Query(ctx, "SELECT l.id, l.entry_type, l.amount, l.time_create, COALESCE(l.position_id, 0), "+
+		"COALESCE(l.order_id, 0), COALESCE(p.symbol_id, 0), COALESCE(p.symbol_title, ''), "+
+		"CASE WHEN l.entry_type = 'position_close' THEN COALESCE(p.pnl, 0) ELSE 0 END "+
+		"FROM ledger_entries l LEFT JOIN positions p ON p.id = l.position_id "+
+		"WHERE l.user_id = $1 AND l.account = $2 AND ($3 = 0 OR l.id < $3) "+
+		"AND ($4::timestamp IS NULL OR l.time_create >= $4) AND ($5::timestamp IS NULL OR l.time_create < $5) "+
+		"AND ($6 = 0 OR p.symbol_id = $6) AND (cardinality($7::text[]) = 0 OR l.entry_type = ANY($7)) "+
+		"ORDER BY l.id DESC LIMIT $8",
+		history.UserID, model.AccountUser, history.Cursor, nullTime(history.From), nullTime(history.To),
+		history.SymbolID, types, history.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.AccountEntry
+	for rows.Next() {
+		var entry model.AccountEntry
+		var entryType string
+		err = rows.Scan(&entry.ID, &entryType, &entry.Amount, &entry.Time, &entry.PositionID, &entry.OrderID,
+			&entry.SymbolID, &entry.SymbolTitle, &entry.Pnl)
+		if err != nil {
+			return nil, err
+		}
+		entry.Type, err = model.ParseEntryType(entryType)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// PlaceOrder func stores pending order. Returns id of order, error
+func (r *Postgres) PlaceOrder(ctx context.Context, order *request.PlaceOrder, t time.Time) (int32, error) {
+	var id int32
+	err := r.conn.QueryRow(ctx, "INSERT INTO orders (id, user_id, symbol_id, order_type, count, price, stop_loss, "+
+		"take_profit, is_buy, time_create, time_fill, position_id) "+
+		"VALUES (nextval('orders_sequence'), $1, $2, $3, $4, $5, $6, $7, $8, $9, NULL, NULL) RETURNING id",
+		order.UserID, order.SymbolID, order.Type, order.Count, order.Price, order.StopLoss, order.TakeProfit, order.IsBuy,
+		t).Scan(&id)
 	if err != nil {
-		log.Error(err)
 		return 0, err
 	}
-	return userID, nil
+	return id, nil
 }
 
-// ChangeBalance changes user's balance
-func (r *Repository) ChangeBalance(ctx context.Context, userID int32, sum float32) error {
-	commandTag, err := r.conn.Exec(ctx, "UPDATE users SET balance = balance + $1 where id = $2", sum, userID)
+// FillOrder marks order as filled by the position
+func (r *Postgres) FillOrder(ctx context.Context, orderID, positionID int32) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE orders SET time_fill = CURRENT_TIMESTAMP, position_id = $1 "+
+		"WHERE id = $2 AND time_fill IS NULL AND time_reject IS NULL", positionID, orderID)
 	if err != nil {
 		return err
 	}
 	if commandTag.RowsAffected() != 1 {
-		return errors.New("balance didn't change")
+		return errors.New("order didn't fill")
+	}
+	return nil
+}
+
+// RejectOrder marks pending order as rejected for the reason, it isn't pending anymore
+func (r *Postgres) RejectOrder(ctx context.Context, orderID int32, reason string) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE orders SET time_reject = CURRENT_TIMESTAMP, reject_reason = $1 "+
+		"WHERE id = $2 AND time_fill IS NULL AND time_reject IS NULL", reason, orderID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 1 {
+		return errors.New("order didn't reject")
 	}
 	return nil
 }
+
+// GetPendingOrders returns all orders of the certain user which have neither filled nor been rejected
+func (r *Postgres) GetPendingOrders(userID int32) (map[int32]*model.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	rows, err := r.conn.Query(ctx, "SELECT id, user_id, symbol_id, order_type, count, price, stop_loss, take_profit, "+
+		"is_buy, time_create FROM orders WHERE user_id = $1 AND time_fill IS NULL AND time_reject IS NULL", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make(map[int32]*model.Order)
+	for rows.Next() {
+		var order model.Order
+		err = rows.Scan(&order.ID, &order.UserID, &order.SymbolID, &order.Type, &order.Count, &order.Price, &order.StopLoss,
+			&order.TakeProfit, &order.IsBuy, &order.TimeCreate)
+		if err != nil {
+			return nil, err
+		}
+		orders[order.ID] = &order
+	}
+	return orders, nil
+}
+
+// GetSymbols returns all symbols
+func (r *Postgres) GetSymbols(ctx context.Context) (map[int32]*model.Symbol, error) {
+	rows, err := r.conn.Query(ctx, "SELECT id, ticker, title, tradable, lot_size, tick_size, currency FROM symbols")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	symbols := make(map[int32]*model.Symbol)
+	for rows.Next() {
+		var symbol model.Symbol
+		err = rows.Scan(&symbol.ID, &symbol.Ticker, &symbol.Title, &symbol.Tradable, &symbol.LotSize, &symbol.TickSize,
+			&symbol.Currency)
+		if err != nil {
+			return nil, err
+		}
+		symbols[symbol.ID] = &symbol
+	}
+	return symbols, rows.Err()
+}
+
+// AddSymbol stores new tradable symbol. Returns id of symbol
+func (r *Postgres) AddSymbol(ctx context.Context, symbol *request.AddSymbol) (int32, error) {
+	var id int32
+	err := r.conn.QueryRow(ctx, "INSERT INTO symbols (id, ticker, title, tradable, lot_size, tick_size, currency) "+
+		"VALUES (nextval('symbols_sequence'), $1, $2, true, $3, $4, $5) ON CONFLICT (ticker) DO NOTHING RETURNING id",
+		symbol.Ticker, symbol.Title, symbol.LotSize, symbol.TickSize, symbol.Currency).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("ticker %s is already taken", symbol.Ticker)
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// SetSymbolTradable allows or forbids trading the symbol
+func (r *Postgres) SetSymbolTradable(ctx context.Context, symbolID int32, tradable bool) error {
+	commandTag, err := r.conn.Exec(ctx, "UPDATE symbols SET tradable = $1 WHERE id = $2", tradable, symbolID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// nullID returns NULL for zero id which means that there is no link
+func nullID(id int32) *int32 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// nullTime returns NULL for zero time which means that there is no bound
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -3,40 +3,151 @@ package request
 
 import (
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
 
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrOrderRejected means that the order can never be filled. It is rejected and isn't pending anymore
+var ErrOrderRejected = errors.New("order rejected")
+
+// SignUp stores credentials and the first deposit of a new user
+type SignUp struct {
+	Login    string
+	Password string
+	Deposit  money.Amount
+}
+
 // OpenPositionRepository stores parameters for opening a position in the repository
 type OpenPositionRepository struct {
-	UserID      int32
-	SymbolID    int32
-	SymbolTitle string
-	Count       int32
-	PriceOpen   float32
-	StopLoss    float32
-	TakeProfit  float32
-	IsBuy       bool
+	UserID       int32
+	SymbolID     int32
+	SymbolTitle  string
+	Count        int32
+	PriceOpen    money.Amount
+	StopLoss     money.Amount
+	TakeProfit   money.Amount
+	TrailingStop money.Amount
+	IsBuy        bool
+	Margin       money.Amount
 }
 
 // OpenPositionService stores parameters for opening a position in the service
 type OpenPositionService struct {
+	UserID       int32
+	SymbolID     int32
+	Price        money.Amount
+	Count        int32
+	StopLoss     money.Amount
+	TakeProfit   money.Amount
+	TrailingStop money.Amount
+	IsBuy        bool
+}
+
+// ModifyPosition stores new stop loss and take profit of a position
+type ModifyPosition struct {
 	UserID     int32
-	SymbolID   int32
-	Price      float32
+	PositionID int32
+	StopLoss   money.Amount
+	TakeProfit money.Amount
+}
+
+// ClosePositionService stores parameters for closing a position in the service. Count 0 closes the whole position
+type ClosePositionService struct {
+	UserID     int32
+	PositionID int32
 	Count      int32
-	StopLoss   float32
-	TakeProfit float32
-	IsBuy      bool
 }
 
-// ClosePosition stores fields when closing a position
+// ClosePosition stores fields when closing a position. Count is used when a part of the position is closed
 type ClosePosition struct {
 	ID         int32
-	PriceClose float32
+	Count      int32
+	PriceClose money.Amount
+	Pnl        money.Amount
+	Margin     money.Amount // margin released by the closed count
+	Reason     model.CloseReason
+}
+
+// ChangeBalance stores a balance movement and its cause. PositionID and OrderID are zero if there is no link
+type ChangeBalance struct {
+	UserID     int32
+	Sum        money.Amount
+	Type       model.EntryType
+	PositionID int32
+	OrderID    int32
+}
+
+// PlaceOrder stores parameters for placing a pending order
+type PlaceOrder struct {
+	UserID     int32
+	SymbolID   int32
+	Type       model.OrderType
+	Price      money.Amount
+	Count      int32
+	StopLoss   money.Amount
+	TakeProfit money.Amount
+	IsBuy      bool
 }
 
-// PositionCloser closes a position
+// PositionCloser closes a position for the reason
 type PositionCloser interface {
-	Close(ctx context.Context, position *model.Position) error
+	Close(ctx context.Context, position *model.Position, reason model.CloseReason) error
+}
+
+// StopLossMover stores a new stop loss of a position
+type StopLossMover interface {
+	MoveStopLoss(ctx context.Context, position *model.Position) error
+}
+
+// PriceSubscriber receives prices of the symbols which it has subscribed to. Receive mustn't block
+type PriceSubscriber interface {
+	Receive(price *model.Price)
+}
+
+// PriceRegistry delivers prices of a symbol only to the subscribers of it
+type PriceRegistry interface {
+	Subscribe(symbolID int32, subscriber PriceSubscriber)
+	Unsubscribe(symbolID int32, subscriber PriceSubscriber)
+}
+
+// MarginAuditor stores steps of the margin control
+type MarginAuditor interface {
+	AuditMargin(ctx context.Context, event *model.MarginEvent) error
+}
+
+// OrderExecutor turns a triggered pending order into a position. It returns ErrOrderRejected if the order has
+// been rejected, other errors may pass on the next price
+type OrderExecutor interface {
+	Execute(ctx context.Context, order *model.Order) error
+}
+
+// AccountHistory stores filters of the account history. Zero From, To and SymbolID and empty Types don't filter.
+// The history is read from the newest entry, Cursor is id of the last entry of the previous page
+type AccountHistory struct {
+	UserID   int32
+	From     time.Time
+	To       time.Time
+	SymbolID int32
+	Types    []model.EntryType
+	Cursor   int32
+	Limit    int32
+}
+
+// ListPositions stores a page of positions. Cursor is id of the last position of the previous page
+type ListPositions struct {
+	UserID int32
+	Cursor int32
+	Limit  int32
+}
+
+// AddSymbol stores parameters of a new symbol
+type AddSymbol struct {
+	Ticker   string
+	Title    string
+	LotSize  int32
+	TickSize money.Amount
+	Currency string
 }
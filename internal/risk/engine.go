@@ -0,0 +1,283 @@
+// Package risk controls open positions by prices apart from the goroutines of users. It closes positions by stop
+// loss, take profit and stop out
+package risk
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	log "github.com/sirupsen/logrus"
+
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Account owns open positions which the engine controls
+type Account interface {
+	GetID() int32
+	// UpdatePosition applies the price to the position. It mustn't block. Returns true if trailing stop has moved
+	// the stop loss, and the reason if the position must be closed
+	UpdatePosition(position *model.Position, price *model.Price) (moved bool, reason model.CloseReason, ok bool)
+	// MoveStopLoss stores the stop loss of the position which trailing stop has moved
+	MoveStopLoss(ctx context.Context, position *model.Position) error
+	// Close closes the position for the reason
+	Close(ctx context.Context, position *model.Position, reason model.CloseReason) error
+	// ControlMargin closes positions while the margin level is below stop out. Returns the closed positions
+	ControlMargin(ctx context.Context) []*model.Position
+}
+
+// holding is an open position with its owner
+type holding struct {
+	position *model.Position
+	account  Account
+}
+
+// Stats shows how the engine keeps up with prices. Blocked is a count of jobs which have waited for a full queue,
+// BlockedTime is the time they have waited. Prices are delayed while a queue is full
+type Stats struct {
+	Workers     int
+	Capacity    int // capacity of all queues
+	Queued      int // jobs waiting in the queues now
+	Positions   int // open positions in the index
+	Ticks       int64
+	Checked     int64 // positions checked by prices
+	Submitted   int64
+	Done        int64
+	Failed      int64
+	Blocked     int64
+	BlockedTime time.Duration
+}
+
+// Engine keeps an index from symbol to open positions and checks only the positions of the symbol whose price has
+// changed. Closes run on a bounded pool of workers. Jobs of an account always go to the same worker, so they run
+// one by one in order
+type Engine struct {
+	muIndex sync.RWMutex
+	index   map[int32]map[int32]holding // map[symbolID]map[position.ID]holding
+	queues  []chan func()
+
+	muPending sync.Mutex
+	closing   map[int32]struct{} // ids of positions whose close is queued
+	moving    map[int32]struct{} // ids of positions whose stop loss is queued for storing
+	margins   map[int32]struct{} // ids of accounts whose margin control is queued
+
+	ticks       int64
+	checked     int64
+	submitted   int64
+	done        int64
+	failed      int64
+	blocked     int64
+	blockedTime int64 // nanoseconds
+}
+
+// NewEngine is constructor. It starts the workers which stop when ctx is done. Each worker has a queue of queueSize
+// jobs, a price waits while the queue of its account is full
+func NewEngine(ctx context.Context, workers, queueSize int) (*Engine, error) {
+	if workers <= 0 || queueSize <= 0 {
+		return nil, errors.New("count of workers and size of queue must be positive")
+	}
+	e := Engine{
+		index:   make(map[int32]map[int32]holding),
+		queues:  make([]chan func(), workers),
+		closing: make(map[int32]struct{}),
+		moving:  make(map[int32]struct{}),
+		margins: make(map[int32]struct{}),
+	}
+	for i := range e.queues {
+		e.queues[i] = make(chan func(), queueSize)
+		go e.work(ctx, e.queues[i])
+	}
+	return &e, nil
+}
+
+// Watch adds the open position of the account to the index
+func (e *Engine) Watch(account Account, position *model.Position) {
+	e.muIndex.Lock()
+	defer e.muIndex.Unlock()
+	positions, ok := e.index[position.SymbolID]
+	if !ok {
+		positions = make(map[int32]holding)
+		e.index[position.SymbolID] = positions
+	}
+	positions[position.ID] = holding{position: position, account: account}
+}
+
+// Unwatch deletes the position from the index after it has been closed
+func (e *Engine) Unwatch(symbolID, positionID int32) {
+	e.muIndex.Lock()
+	defer e.muIndex.Unlock()
+	positions, ok := e.index[symbolID]
+	if !ok {
+		return
+	}
+	delete(positions, positionID)
+	if len(positions) == 0 {
+		delete(e.index, symbolID)
+	}
+}
+
+// Watching returns true if the index has open positions of the symbol
+func (e *Engine) Watching(symbolID int32) bool {
+	e.muIndex.RLock()
+	defer e.muIndex.RUnlock()
+	return len(e.index[symbolID]) > 0
+}
+
+// Update applies the price to the open positions of its symbol. Stop losses moved by trailing stop are queued for
+// storing, positions which have reached stop loss or take profit are queued for closing, then margin of their
+// accounts is queued for control. Only the workers touch the database
+func (e *Engine) Update(ctx context.Context, price *model.Price) {
+	atomic.AddInt64(&e.ticks, 1)
+	e.muIndex.RLock()
+	holdings := make([]holding, 0, len(e.index[price.ID]))
+	for _, h := range e.index[price.ID] {
+		holdings = append(holdings, h)
+	}
+	e.muIndex.RUnlock()
+
+	accounts := make(map[int32]Account)
+	for _, h := range holdings {
+		atomic.AddInt64(&e.checked, 1)
+		accounts[h.account.GetID()] = h.account
+		moved, reason, ok := h.account.UpdatePosition(h.position, price)
+		if moved {
+			e.moveStopLoss(ctx, h)
+		}
+		if ok {
+			e.close(ctx, h, reason)
+		}
+	}
+	for _, account := range accounts {
+		e.controlMargin(ctx, account)
+	}
+}
+
+// Stats returns counters of the engine since it has started
+func (e *Engine) Stats() *Stats {
+	stats := Stats{
+		Workers:     len(e.queues),
+		Ticks:       atomic.LoadInt64(&e.ticks),
+		Checked:     atomic.LoadInt64(&e.checked),
+		Submitted:   atomic.LoadInt64(&e.submitted),
+		Done:        atomic.LoadInt64(&e.done),
+		Failed:      atomic.LoadInt64(&e.failed),
+		Blocked:     atomic.LoadInt64(&e.blocked),
+		BlockedTime: time.Duration(atomic.LoadInt64(&e.blockedTime)),
+	}
+	for _, queue := range e.queues {
+		stats.Capacity += cap(queue)
+		stats.Queued += len(queue)
+	}
+	e.muIndex.RLock()
+	for _, positions := range e.index {
+		stats.Positions += len(positions)
+	}
+	e.muIndex.RUnlock()
+	return &stats
+}
+
+// close queues closing of the position unless it is queued already
+func (e *Engine) close(ctx context.Context, h holding, reason model.CloseReason) {
+	e.muPending.Lock()
+	if _, ok := e.closing[h.position.ID]; ok {
+		e.muPending.Unlock()
+		return
+	}
+	e.closing[h.position.ID] = struct{}{}
+	e.muPending.Unlock()
+
+	symbolID, positionID := h.position.SymbolID, h.position.ID
+	e.submit(ctx, h.account.GetID(), func() error {
+		defer func() {
+			e.muPending.Lock()
+			delete(e.closing, positionID)
+			e.muPending.Unlock()
+		}()
+		if err := h.account.Close(ctx, h.position, reason); err != nil {
+			return err
+		}
+		e.Unwatch(symbolID, positionID)
+		return nil
+	})
+}
+
+// moveStopLoss queues storing of the stop loss unless it is queued already. The job stores the stop loss which
+// the position has when the job runs, so ticks which come faster than the database coalesce
+func (e *Engine) moveStopLoss(ctx context.Context, h holding) {
+	e.muPending.Lock()
+	if _, ok := e.moving[h.position.ID]; ok {
+		e.muPending.Unlock()
+		return
+	}
+	e.moving[h.position.ID] = struct{}{}
+	e.muPending.Unlock()
+
+	positionID := h.position.ID
+	e.submit(ctx, h.account.GetID(), func() error {
+		e.muPending.Lock()
+		delete(e.moving, positionID)
+		e.muPending.Unlock()
+		return h.account.MoveStopLoss(ctx, h.position)
+	})
+}
+
+// controlMargin queues margin control of the account unless it is queued already
+func (e *Engine) controlMargin(ctx context.Context, account Account) {
+	accountID := account.GetID()
+	e.muPending.Lock()
+	if _, ok := e.margins[accountID]; ok {
+		e.muPending.Unlock()
+		return
+	}
+	e.margins[accountID] = struct{}{}
+	e.muPending.Unlock()
+
+	e.submit(ctx, accountID, func() error {
+		e.muPending.Lock()
+		delete(e.margins, accountID)
+		e.muPending.Unlock()
+		for _, position := range account.ControlMargin(ctx) {
+			e.Unwatch(position.SymbolID, position.ID)
+		}
+		return nil
+	})
+}
+
+// submit puts the job to the queue of the account. If the queue is full, it waits and counts the wait
+func (e *Engine) submit(ctx context.Context, accountID int32, job func() error) {
+	queue := e.queues[int(uint32(accountID))%len(e.queues)]
+	atomic.AddInt64(&e.submitted, 1)
+	run := func() {
+		if err := job(); err != nil {
+			atomic.AddInt64(&e.failed, 1)
+			log.Error(err)
+		}
+		atomic.AddInt64(&e.done, 1)
+	}
+	select {
+	case queue <- run:
+		return
+	default:
+	}
+	atomic.AddInt64(&e.blocked, 1)
+	log.Warnf("queue of the risk engine is full, account %d waits", accountID)
+	start := time.Now()
+	select {
+	case queue <- run:
+	case <-ctx.Done():
+	}
+	atomic.AddInt64(&e.blockedTime, int64(time.Since(start)))
+}
+
+// work runs jobs from the queue until ctx is done
+func (e *Engine) work(ctx context.Context, queue chan func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-queue:
+			job()
+		}
+	}
+}
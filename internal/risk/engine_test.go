@@ -0,0 +1,166 @@
+package risk
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testAccount closes positions by stop loss at the price, trails stop loss and counts calls. ControlMargin and
+// MoveStopLoss wait for release
+type testAccount struct {
+	id      int32
+	release chan struct{}
+
+	mu      sync.Mutex
+	updated []int32
+	closed  []int32
+	margins int
+	moved   []money.Amount // stored stop losses
+}
+
+func (a *testAccount) GetID() int32 {
+	return a.id
+}
+
+func (a *testAccount) UpdatePosition(position *model.Position, price *model.Price) (bool, model.CloseReason, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.updated = append(a.updated, position.ID)
+	moved := position.TrailingStop > 0 && price.Bid-position.TrailingStop > position.StopLoss
+	if moved {
+		position.StopLoss = price.Bid - position.TrailingStop
+	}
+	return moved, model.CloseStopLoss, price.Bid <= position.StopLoss
+}
+
+func (a *testAccount) MoveStopLoss(ctx context.Context, position *model.Position) error {
+	if a.release != nil {
+		<-a.release
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.moved = append(a.moved, position.StopLoss)
+	return nil
+}
+
+func (a *testAccount) Close(ctx context.Context, position *model.Position, reason model.CloseReason) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = append(a.closed, position.ID)
+	return nil
+}
+
+func (a *testAccount) ControlMargin(ctx context.Context) []*model.Position {
+	if a.release != nil {
+		<-a.release
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.margins++
+	return nil
+}
+
+func (a *testAccount) calls() (updated, closed []int32, margins int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]int32(nil), a.updated...), append([]int32(nil), a.closed...), a.margins
+}
+
+func TestEngine_Update(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e, err := NewEngine(ctx, 2, 4)
+	require.NoError(t, err)
+
+	account := &testAccount{id: 1}
+	e.Watch(account, &model.Position{ID: 1, SymbolID: 1, StopLoss: money.New(9)})
+	e.Watch(account, &model.Position{ID: 2, SymbolID: 1, StopLoss: money.New(5)})
+	e.Watch(account, &model.Position{ID: 3, SymbolID: 2, StopLoss: money.New(9)})
+
+	e.Update(ctx, &model.Price{ID: 1, Bid: money.New(8), Ask: money.New(8)})
+	require.Eventually(t, func() bool {
+		return e.Stats().Done == 2
+	}, time.Second, time.Millisecond)
+
+	updated, closed, margins := account.calls()
+	assert.ElementsMatch(t, []int32{1, 2}, updated, "only positions of the symbol are checked")
+	assert.Equal(t, []int32{1}, closed)
+	assert.Equal(t, 1, margins, "margin of the account is controlled once per price")
+
+	stats := e.Stats()
+	assert.Equal(t, int64(1), stats.Ticks)
+	assert.Equal(t, int64(2), stats.Checked)
+	assert.Equal(t, 2, stats.Positions, "the closed position is deleted from the index")
+	assert.Equal(t, 8, stats.Capacity)
+	assert.Zero(t, stats.Blocked)
+}
+
+func TestEngine_moveStopLoss(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e, err := NewEngine(ctx, 1, 4)
+	require.NoError(t, err)
+
+	account := &testAccount{id: 1, release: make(chan struct{})}
+	e.Watch(account, &model.Position{ID: 1, SymbolID: 1, TrailingStop: money.New(1)})
+
+	e.Update(ctx, &model.Price{ID: 1, Bid: money.New(10), Ask: money.New(10)})
+	e.Update(ctx, &model.Price{ID: 1, Bid: money.New(12), Ask: money.New(12)})
+	account.mu.Lock()
+	assert.Empty(t, account.moved, "prices don't wait for the database")
+	account.mu.Unlock()
+
+	close(account.release)
+	require.Eventually(t, func() bool {
+		stats := e.Stats()
+		return stats.Queued == 0 && stats.Done == stats.Submitted
+	}, time.Second, time.Millisecond)
+	account.mu.Lock()
+	defer account.mu.Unlock()
+	require.NotEmpty(t, account.moved)
+	assert.Equal(t, money.New(11), account.moved[len(account.moved)-1], "the latest stop loss is stored")
+}
+
+func TestEngine_backpressure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e, err := NewEngine(ctx, 1, 1)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	accounts := make([]*testAccount, 3)
+	for i := range accounts {
+		accounts[i] = &testAccount{id: int32(i + 1), release: release}
+		e.Watch(accounts[i], &model.Position{ID: int32(i + 1), SymbolID: 1})
+	}
+
+	updated := make(chan struct{})
+	go func() {
+		e.Update(ctx, &model.Price{ID: 1, Bid: money.New(10), Ask: money.New(10)})
+		close(updated)
+	}()
+	require.Eventually(t, func() bool {
+		return e.Stats().Blocked > 0
+	}, time.Second, time.Millisecond, "a job waits for the full queue")
+	select {
+	case <-updated:
+		t.Fatal("price mustn't be applied while the queue is full")
+	default:
+	}
+
+	close(release)
+	<-updated
+	require.Eventually(t, func() bool {
+		return e.Stats().Done == 3
+	}, time.Second, time.Millisecond)
+	stats := e.Stats()
+	assert.Zero(t, stats.Queued)
+	assert.Positive(t, stats.BlockedTime)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"github.com/chucky-1/broker/internal/feed"
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/request"
+
+	"sync"
+)
+
+// registry delivers a price of a symbol only to the subscribers of the symbol, so the price doesn't reach users
+// without orders and clients which don't stream it
+type registry struct {
+	mu          sync.RWMutex
+	subscribers map[int32]map[request.PriceSubscriber]struct{} // map[symbolID]set of subscribers
+}
+
+func newRegistry() *registry {
+	return &registry{subscribers: make(map[int32]map[request.PriceSubscriber]struct{})}
+}
+
+// Subscribe adds the subscriber to subscribers of the symbol. It does nothing if it has subscribed already
+func (r *registry) Subscribe(symbolID int32, subscriber request.PriceSubscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscribers, ok := r.subscribers[symbolID]
+	if !ok {
+		subscribers = make(map[request.PriceSubscriber]struct{})
+		r.subscribers[symbolID] = subscribers
+	}
+	subscribers[subscriber] = struct{}{}
+}
+
+// Unsubscribe deletes the subscriber from subscribers of the symbol. The registry doesn't deliver prices to it
+// after Unsubscribe has returned
+func (r *registry) Unsubscribe(symbolID int32, subscriber request.PriceSubscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscribers, ok := r.subscribers[symbolID]
+	if !ok {
+		return
+	}
+	delete(subscribers, subscriber)
+	if len(subscribers) == 0 {
+		delete(r.subscribers, symbolID)
+	}
+}
+
+// count returns count of subscribers of the symbol
+func (r *registry) count(symbolID int32) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.subscribers[symbolID])
+}
+
+// publish delivers the price to subscribers of its symbol
+func (r *registry) publish(price *model.Price) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for subscriber := range r.subscribers[price.ID] {
+		subscriber.Receive(price)
+	}
+}
+
+// priceStream is a stream of prices to a client
+type priceStream chan *model.Price
+
+// Receive sends the price to the stream without blocking. If the client is slow, its oldest price is dropped
+// in favor of the latest one
+func (s priceStream) Receive(price *model.Price) {
+	feed.Send(s, price)
+}
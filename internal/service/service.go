@@ -2,41 +2,111 @@
 package service
 
 import (
+	"github.com/chucky-1/broker/internal/auth"
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
 	"github.com/chucky-1/broker/internal/repository"
 	"github.com/chucky-1/broker/internal/request"
+	"github.com/chucky-1/broker/internal/risk"
 	"github.com/chucky-1/broker/internal/user"
 	log "github.com/sirupsen/logrus"
 
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
 
+var (
+	// ErrPositionNotFound means that the position doesn't exist or has been closed
+	ErrPositionNotFound = errors.New("position didn't find")
+	// ErrPermissionDenied means that the position belongs to another user
+	ErrPermissionDenied = errors.New("position belongs to another user")
+	// ErrSymbolNotFound means that the broker doesn't know the symbol
+	ErrSymbolNotFound = errors.New("symbol didn't find")
+	// ErrSymbolNotTradable means that the symbol has been disabled
+	ErrSymbolNotTradable = errors.New("symbol isn't tradable")
+	// ErrUserNotFound means that the user with the id hasn't signed up
+	ErrUserNotFound = errors.New("user didn't find")
+	// ErrNoQuote means that no price of the symbol has arrived from the pricer yet or the last one is too old
+	ErrNoQuote = errors.New("no quote yet, the market of the symbol is closed")
+	// ErrNotEnoughMargin means that the free margin doesn't cover the margin of a new position
+	ErrNotEnoughMargin = errors.New("not enough free margin")
+	// ErrPriceChanged means that the current price is worse than the price the user has seen
+	ErrPriceChanged = errors.New("price changed. Try again")
+)
+
+// priceBuffer is a count of prices which a slow subscriber may not read before the oldest of them are dropped
+const priceBuffer = 64
+
+// symbolBuffer is a count of symbol events which may wait for the pricer subscription
+const symbolBuffer = 16
+
+// maxLeverage is the largest leverage of an account
+const maxLeverage = 500
+
+const (
+	// pageSize is a count of items in a page of a list if the client hasn't chosen it
+	pageSize = 50
+	// maxPageSize is the largest page of a list
+	maxPageSize = 500
+)
+
 // Service implements business logic
 type Service struct {
-	muRep     sync.Mutex
-	rep       *repository.Repository
-	muSymbols sync.RWMutex
-	symbols   map[int32]*model.Symbol // map[symbol.ID]*symbol
-	muUsers   sync.RWMutex
-	users     map[int32]*user.User // map[user.ID]*user
-	chPrice   chan *model.Price
-	muPrices  sync.RWMutex
-	prices    map[int32]*model.Price
-}
-
-// NewService is constructor
-func NewService(ctx context.Context, rep *repository.Repository, chPrice chan *model.Price,
-	symbols map[int32]*model.Symbol) (*Service, error) {
+	rep           repository.Repository
+	leverage      int32 // leverage of new accounts
+	levels        model.MarginLevels
+	engine        *risk.Engine
+	muSymbols     sync.RWMutex
+	symbols       map[int32]*model.Symbol // map[symbol.ID]*symbol, a symbol is replaced instead of changing
+	released      map[int32]struct{}      // disabled symbols whose prices the broker doesn't need any more
+	chSymbols     chan *model.SymbolEvent
+	muUsers       sync.RWMutex
+	users         map[int32]*user.User // map[user.ID]*user
+	registry      *registry            // users which wait for prices of a symbol
+	streams       *registry            // clients which stream prices of a symbol
+	chPrice       chan *model.Price
+	muPrices      sync.RWMutex
+	prices        map[int32]*model.Price
+	received      map[int32]time.Time // when the last price of the symbol has arrived
+	quoteTTL      time.Duration       // a price older than it isn't used for trading
+}
+
+// NewService is constructor. New accounts get the leverage, all accounts are controlled by the margin levels.
+// The engine closes open positions by prices. Positions don't open and close by a price older than quoteTTL
+func NewService(ctx context.Context, rep repository.Repository, chPrice chan *model.Price, leverage int32,
+	levels model.MarginLevels, engine *risk.Engine, quoteTTL time.Duration) (*Service, error) {
+	if err := checkLeverage(leverage); err != nil {
+		return nil, err
+	}
+	if levels.StopOut <= 0 || levels.StopOut >= levels.MarginCall {
+		return nil, errors.New("stop-out level must be positive and below margin-call level")
+	}
+	if quoteTTL <= 0 {
+		return nil, errors.New("quote ttl must be positive")
+	}
+	symbols, err := rep.GetSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
 	s := Service{
-		rep:     rep,
-		symbols: symbols,
-		users:   make(map[int32]*user.User),
-		chPrice: chPrice,
-		prices:  make(map[int32]*model.Price),
+		rep:       rep,
+		leverage:  leverage,
+		levels:    levels,
+		engine:    engine,
+		symbols:   symbols,
+		released:  make(map[int32]struct{}),
+		chSymbols: make(chan *model.SymbolEvent, symbolBuffer),
+		users:     make(map[int32]*user.User),
+		registry:  newRegistry(),
+		streams:   newRegistry(),
+		chPrice:   chPrice,
+		prices:    make(map[int32]*model.Price),
+		received:  make(map[int32]time.Time),
+		quoteTTL:  quoteTTL,
 	}
 	go func(ctx context.Context) {
 		for {
@@ -44,18 +114,15 @@ func NewService(ctx context.Context, rep *repository.Repository, chPrice chan *m
 			case <-ctx.Done():
 				return
 			case price := <-chPrice:
-				s.muPrices.Lock()
-				s.prices[price.ID] = price
-				s.muPrices.Unlock()
-				for _, u := range s.users {
-					u.GetChanPrice() <- price
-				}
+				s.setPrice(price)
+				s.streams.publish(price)
+				s.engine.Update(ctx, price)
+				s.registry.publish(price)
+				s.releaseSymbol(price.ID)
 			}
 		}
 	}(ctx)
-	s.muRep.Lock()
 	users, err := s.rep.GetAllUsers()
-	s.muRep.Unlock()
 	if err != nil {
 		return nil, err
 	}
@@ -76,29 +143,120 @@ func NewService(ctx context.Context, rep *repository.Repository, chPrice chan *m
 				m[position.ID] = position
 			}
 		}
+		orders := new(sync.Map)
+		pendingOrders, err := rep.GetPendingOrders(u.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range pendingOrders {
+			allOrders, ok := orders.Load(order.SymbolID)
+			if !ok {
+				m := make(map[int32]*model.Order)
+				m[order.ID] = order
+				orders.Store(order.SymbolID, m)
+			} else {
+				m := allOrders.(map[int32]*model.Order)
+				m[order.ID] = order
+			}
+		}
 		var closer request.PositionCloser = &s
-		newUser, err := user.NewUser(ctx, u.ID, u.Balance, positions, closer)
+		var executor request.OrderExecutor = &s
+		var mover request.StopLossMover = &s
+		var auditor request.MarginAuditor = &s
+		newUser, err := user.NewUser(ctx, u.ID, u.Balance, u.Leverage, levels, positions, orders, closer, executor,
+			mover, auditor, s.registry)
 		if err != nil {
 			log.Error(err)
 		} else {
 			s.muUsers.Lock()
 			s.users[newUser.GetID()] = newUser
 			s.muUsers.Unlock()
+			for _, position := range openPositions {
+				engine.Watch(newUser, position)
+			}
+		}
+	}
+	// the pricer hasn't subscribed yet, so disabled symbols which aren't in use are released without events
+	for id, symbol := range symbols {
+		if !symbol.Tradable && !s.inUse(id) {
+			s.released[id] = struct{}{}
 		}
 	}
 	return &s, nil
 }
 
+// SubscribePrices returns chan which receives every price of the symbols. The chan is closed when ctx is done
+func (s *Service) SubscribePrices(ctx context.Context, symbolIDs []int32) (<-chan *model.Price, error) {
+	if len(symbolIDs) == 0 {
+		return nil, errors.New("choose at least one symbol")
+	}
+	s.muSymbols.RLock()
+	for _, id := range symbolIDs {
+		if _, ok := s.symbols[id]; !ok {
+			s.muSymbols.RUnlock()
+			return nil, fmt.Errorf("%w: %d", ErrSymbolNotFound, id)
+		}
+	}
+	s.muSymbols.RUnlock()
+
+	stream := make(priceStream, priceBuffer)
+	for _, id := range symbolIDs {
+		s.streams.Subscribe(id, stream)
+	}
+	go func() {
+		<-ctx.Done()
+		// the registry doesn't send to the stream after it has unsubscribed, so the stream may be closed
+		for _, id := range symbolIDs {
+			s.streams.Unsubscribe(id, stream)
+		}
+		close(stream)
+	}()
+	return stream, nil
+}
+
+// StreamPositions returns chan which receives current prices and pnl of user's open positions and
+// events of their closing. The chan is closed when ctx is done
+func (s *Service) StreamPositions(ctx context.Context, userID int32) (<-chan *model.PositionEvent, error) {
+	s.muUsers.RLock()
+	u, ok := s.users[userID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+	}
+	return u.SubscribePositions(ctx), nil
+}
+
 // SignUp implements user registration
-func (s *Service) SignUp(ctx context.Context, deposit float32) (int32, error) {
-	s.muRep.Lock()
-	u, err := s.rep.SignUp(ctx, deposit)
-	s.muRep.Unlock()
+func (s *Service) SignUp(ctx context.Context, r *request.SignUp) (int32, error) {
+	if r.Login == "" || r.Password == "" {
+		return 0, errors.New("login and password mustn't be empty")
+	}
+	if r.Deposit < 0 {
+		return 0, errors.New("deposit can't be negative")
+	}
+	hash, err := auth.HashPassword(r.Password)
+	if err != nil {
+		return 0, err
+	}
+	var u *model.User
+	err = s.rep.InTx(ctx, func(rep repository.Repository) error {
+		var err error
+		u, err = rep.SignUp(ctx, r.Login, hash, s.leverage)
+		if err != nil || r.Deposit == 0 {
+			return err
+		}
+		u.Balance = r.Deposit
+		return rep.ChangeBalance(ctx, &request.ChangeBalance{UserID: u.ID, Sum: r.Deposit, Type: model.EntryDeposit})
+	})
 	if err != nil {
 		return 0, err
 	}
 	var closer request.PositionCloser = s
-	newUser, err := user.NewUser(ctx, u.ID, u.Balance, new(sync.Map), closer)
+	var executor request.OrderExecutor = s
+	var mover request.StopLossMover = s
+	var auditor request.MarginAuditor = s
+	newUser, err := user.NewUser(ctx, u.ID, u.Balance, u.Leverage, s.levels, new(sync.Map), new(sync.Map), closer,
+		executor, mover, auditor, s.registry)
 	if err != nil {
 		log.Error(err)
 	} else {
@@ -109,272 +267,751 @@ func (s *Service) SignUp(ctx context.Context, deposit float32) (int32, error) {
 	return u.ID, nil
 }
 
+// SignIn checks credentials of the user. Returns id of user
+func (s *Service) SignIn(ctx context.Context, login, password string) (int32, error) {
+	u, err := s.rep.SignIn(ctx, login)
+	if err != nil || !auth.CheckPassword(u.PasswordHash, password) {
+		return 0, errors.New("wrong login or password")
+	}
+	return u.ID, nil
+}
+
 // OpenPosition opens position for user. Returns id of position
 func (s *Service) OpenPosition(ctx context.Context, r *request.OpenPositionService) (int32, error) {
+	return s.openPosition(ctx, r, 0)
+}
+
+// openPosition opens position and, if orderID isn't zero, marks the order as filled in the same transaction.
+// A position by an order opens at the market price, or at r.Price if it isn't zero and the market is worse.
+// The position reserves a part of its price as margin, the balance changes only when the position closes.
+// The opening is booked to the ledger with zero amount, so the account history shows it
+func (s *Service) openPosition(ctx context.Context, r *request.OpenPositionService, orderID int32) (int32, error) {
 	s.muUsers.RLock()
 	u, ok := s.users[r.UserID]
 	s.muUsers.RUnlock()
 	if !ok {
-		return 0, errors.New("user didn't find. Please, sign up")
+		return 0, fmt.Errorf("%w: %d", ErrUserNotFound, r.UserID)
+	}
+	if r.TrailingStop < 0 {
+		return 0, errors.New("trailing stop can't be negative")
+	}
+	symbol, err := s.tradableSymbol(r.SymbolID, r.Count, r.StopLoss, r.TakeProfit)
+	if err != nil {
+		return 0, err
 	}
 
-	var price float32
+	quote, err := s.quote(r.SymbolID)
+	if err != nil {
+		return 0, err
+	}
+	price := quote.Ask
 	if r.IsBuy {
-		s.muPrices.RLock()
-		price = s.prices[r.SymbolID].Bid
-		s.muPrices.RUnlock()
-		ok = checkPrice(price, r.Price, r.IsBuy)
-		if !ok {
-			return 0, errors.New("price changed. Try again")
-		}
-	} else {
-		s.muPrices.RLock()
-		price = s.prices[r.SymbolID].Ask
-		s.muPrices.RUnlock()
-		ok = checkPrice(price, r.Price, r.IsBuy)
-		if !ok {
-			return 0, errors.New("price changed. Try again")
-		}
+		price = quote.Bid
 	}
-	currentBalance := u.GetBalance()
-	sum := price * float32(r.Count)
-	ok = checkTransaction(currentBalance, sum)
-	if !ok {
-		return 0, errors.New("not enough money")
+	switch {
+	case orderID == 0 && !checkPrice(price, r.Price, r.IsBuy):
+		return 0, ErrPriceChanged
+	case orderID != 0 && r.Price != 0 && !checkPrice(price, r.Price, r.IsBuy):
+		// the price which has triggered the limit order is gone, the order is filled at its own price
+		price = r.Price
+	}
+	notional, err := price.MulChecked(r.Count)
+	if err != nil {
+		return 0, fmt.Errorf("count %d is too large: %w", r.Count, err)
+	}
+	margin := notional.Div(int64(u.GetLeverage()))
+	if !u.ReserveMargin(margin) {
+		return 0, ErrNotEnoughMargin
 	}
 
-	if r.IsBuy {
-		s.muRep.Lock()
-		err := s.rep.ChangeBalance(ctx, r.UserID, -sum)
-		s.muRep.Unlock()
+	t := time.Now()
+
+	var id int32
+	err = s.rep.InTx(ctx, func(rep repository.Repository) error {
+		var err error
+		id, err = rep.OpenPosition(ctx, &request.OpenPositionRepository{
+			UserID:       r.UserID,
+			SymbolID:     r.SymbolID,
+			SymbolTitle:  symbol.Title,
+			Count:        r.Count,
+			PriceOpen:    price,
+			StopLoss:     r.StopLoss,
+			TakeProfit:   r.TakeProfit,
+			TrailingStop: r.TrailingStop,
+			IsBuy:        r.IsBuy,
+			Margin:       margin,
+		}, t)
 		if err != nil {
-			return 0, err
+			return err
 		}
-		u.ChangeBalance(-sum)
-	} else {
-		s.muRep.Lock()
-		err := s.rep.ChangeBalance(ctx, r.UserID, sum)
-		s.muRep.Unlock()
+		err = rep.ChangeBalance(ctx, &request.ChangeBalance{
+			UserID:     r.UserID,
+			Type:       model.EntryPositionOpen,
+			PositionID: id,
+			OrderID:    orderID,
+		})
 		if err != nil {
-			return 0, err
+			return err
 		}
-		u.ChangeBalance(sum)
+		if orderID != 0 {
+			return rep.FillOrder(ctx, orderID, id)
+		}
+		return nil
+	})
+	if err != nil {
+		u.ReleaseMargin(margin)
+		return 0, err
 	}
 
-	t := time.Now()
+	position := model.Position{
+		ID:           id,
+		UserID:       r.UserID,
+		SymbolID:     r.SymbolID,
+		SymbolTitle:  symbol.Title,
+		Count:        r.Count,
+		PriceOpen:    price,
+		TimeOpen:     t,
+		StopLoss:     r.StopLoss,
+		TakeProfit:   r.TakeProfit,
+		TrailingStop: r.TrailingStop,
+		IsBuy:        r.IsBuy,
+		Margin:       margin,
+		BidClose:     quote.Bid,
+		AskClose:     quote.Ask,
+	}
+	u.OpenPosition(&position)
+	s.engine.Watch(u, &position)
+	return id, nil
+}
 
-	s.muSymbols.RLock()
-	title := s.symbols[r.SymbolID].Title
-	s.muSymbols.RUnlock()
+// ClosePosition closes position for user. If count is less than count of the position, only this part is closed
+// and releases its share of the margin. Returns id of the closed position
+func (s *Service) ClosePosition(ctx context.Context, req *request.ClosePositionService) (int32, error) {
+	s.muUsers.RLock()
+	u, ok := s.users[req.UserID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUserNotFound, req.UserID)
+	}
 
-	s.muRep.Lock()
-	id, err := s.rep.OpenPosition(ctx, &request.OpenPositionRepository{
-		UserID:      r.UserID,
-		SymbolID:    r.SymbolID,
-		SymbolTitle: title,
-		Count:       r.Count,
-		PriceOpen:   price,
-		StopLoss:    r.StopLoss,
-		TakeProfit:  r.TakeProfit,
-		IsBuy:       r.IsBuy,
-	}, t)
-	s.muRep.Unlock()
+	position, err := s.ownPosition(ctx, u, req.PositionID)
 	if err != nil {
-		if r.IsBuy {
-			u.ChangeBalance(sum)
-			s.muRep.Lock()
-			err = s.rep.ChangeBalance(ctx, u.GetID(), sum)
-			s.muRep.Unlock()
-			if err != nil {
-				log.Error(err)
+		return 0, err
+	}
+	positionID, count := req.PositionID, req.Count
+	if count == 0 {
+		count = position.Count
+	}
+	if count < 0 || count > position.Count {
+		return 0, fmt.Errorf("count must be between 1 and %d", position.Count)
+	}
+
+	quote, err := s.quote(position.SymbolID)
+	if err != nil {
+		return 0, err
+	}
+	var price money.Amount
+	if position.IsBuy {
+		price = quote.Ask
+	} else {
+		price = quote.Bid
+	}
+	margin := position.Margin
+	if count != position.Count {
+		margin, err = position.Margin.MulChecked(count)
+		if err != nil {
+			return 0, err
+		}
+		margin = margin.Div(int64(position.Count))
+	}
+
+	closedID := positionID
+	r := &request.ClosePosition{
+		ID:         positionID,
+		Count:      count,
+		PriceClose: price,
+		Pnl:        realizedPnl(position, price, count),
+		Margin:     margin,
+		Reason:     model.CloseManual,
+	}
+	err = s.rep.InTx(ctx, func(rep repository.Repository) error {
+		if count == position.Count {
+			if err := rep.ClosePosition(ctx, r); err != nil {
+				return err
 			}
 		} else {
-			u.ChangeBalance(-sum)
-			s.muRep.Lock()
-			err = s.rep.ChangeBalance(ctx, u.GetID(), -sum)
-			s.muRep.Unlock()
-			if err != nil {
-				log.Error(err)
+			var err error
+			if closedID, err = rep.ClosePartOfPosition(ctx, r); err != nil {
+				return err
 			}
 		}
+		return rep.ChangeBalance(ctx, &request.ChangeBalance{
+			UserID:     u.GetID(),
+			Sum:        r.Pnl,
+			Type:       model.EntryPositionClose,
+			PositionID: closedID,
+		})
+	})
+	if err != nil {
 		return 0, err
 	}
+	if count == position.Count {
+		u.ClosePosition(position.SymbolID, positionID, r.Pnl)
+		s.engine.Unwatch(position.SymbolID, positionID)
+	} else {
+		u.ReducePosition(position.SymbolID, positionID, count, margin, r.Pnl)
+	}
+	return closedID, nil
+}
 
-	position := model.Position{
-		ID:          id,
-		UserID:      r.UserID,
-		SymbolID:    r.SymbolID,
-		SymbolTitle: title,
-		Count:       r.Count,
-		PriceOpen:   price,
-		TimeOpen:    t,
-		StopLoss:    r.StopLoss,
-		TakeProfit:  r.TakeProfit,
-		IsBuy:       r.IsBuy,
+// PlaceOrder stores pending order which opens a position when the price triggers it. Returns id of order
+func (s *Service) PlaceOrder(ctx context.Context, r *request.PlaceOrder) (int32, error) {
+	s.muUsers.RLock()
+	u, ok := s.users[r.UserID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUserNotFound, r.UserID)
 	}
-	u.OpenPosition(&position)
+
+	_, err := s.tradableSymbol(r.SymbolID, r.Count, r.Price, r.StopLoss, r.TakeProfit)
+	if err != nil {
+		return 0, err
+	}
+
+	switch r.Type {
+	case model.OrderLimit, model.OrderStop, model.OrderMarketIfTouched:
+	default:
+		return 0, fmt.Errorf("unknown order type %d", r.Type)
+	}
+
+	t := time.Now()
+	id, err := s.rep.PlaceOrder(ctx, r, t)
+	if err != nil {
+		return 0, err
+	}
+
+	u.PlaceOrder(&model.Order{
+		ID:         id,
+		UserID:     r.UserID,
+		SymbolID:   r.SymbolID,
+		Type:       r.Type,
+		Count:      r.Count,
+		Price:      r.Price,
+		StopLoss:   r.StopLoss,
+		TakeProfit: r.TakeProfit,
+		IsBuy:      r.IsBuy,
+		TimeCreate: t,
+	})
 	return id, nil
 }
 
-// ClosePosition closes position for user
-func (s *Service) ClosePosition(ctx context.Context, positionID int32) error {
-	s.muRep.Lock()
-	userID, err := s.rep.GetUserIDByPositionID(ctx, positionID)
-	s.muRep.Unlock()
-	if err != nil {
-		return fmt.Errorf("you did not open a position with id %d", positionID)
+// Execute opens a position by the triggered pending order. Limit order is filled at its price or better,
+// stop and market-if-touched orders are filled at the market price. An order which can never be filled is
+// rejected and request.ErrOrderRejected is returned
+func (s *Service) Execute(ctx context.Context, order *model.Order) error {
+	var price money.Amount // zero opens at the market price
+	if order.Type == model.OrderLimit {
+		price = order.Price
 	}
+	_, err := s.openPosition(ctx, &request.OpenPositionService{
+		UserID:     order.UserID,
+		SymbolID:   order.SymbolID,
+		Price:      price,
+		Count:      order.Count,
+		StopLoss:   order.StopLoss,
+		TakeProfit: order.TakeProfit,
+		IsBuy:      order.IsBuy,
+	}, order.ID)
+	if err == nil || !rejected(err) {
+		return err
+	}
+	if rejectErr := s.rep.RejectOrder(ctx, order.ID, err.Error()); rejectErr != nil {
+		return rejectErr
+	}
+	return fmt.Errorf("%w: %v", request.ErrOrderRejected, err)
+}
+
+// rejected returns true if the order has failed for a reason which the next price doesn't change
+func rejected(err error) bool {
+	return errors.Is(err, ErrNotEnoughMargin) || errors.Is(err, ErrSymbolNotFound) ||
+		errors.Is(err, ErrSymbolNotTradable) || errors.Is(err, money.ErrOverflow)
+}
 
+// ModifyPosition changes stop loss and take profit of the open position
+func (s *Service) ModifyPosition(ctx context.Context, r *request.ModifyPosition) error {
 	s.muUsers.RLock()
-	u := s.users[userID]
+	u, ok := s.users[r.UserID]
 	s.muUsers.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUserNotFound, r.UserID)
+	}
 
-	s.muRep.Lock()
-	position, err := s.rep.GetPosition(ctx, positionID)
-	s.muRep.Unlock()
+	position, err := s.ownPosition(ctx, u, r.PositionID)
 	if err != nil {
 		return err
 	}
 
-	var price float32
-	if position.IsBuy {
-		s.muPrices.RLock()
-		price = s.prices[position.SymbolID].Ask
-		s.muPrices.RUnlock()
-	} else {
-		s.muPrices.RLock()
-		price = s.prices[position.SymbolID].Bid
-		s.muPrices.RUnlock()
+	price, err := s.quote(position.SymbolID)
+	if err != nil {
+		return err
 	}
-	sum := price * float32(position.Count)
-
-	if position.IsBuy {
-		s.muRep.Lock()
-		err = s.rep.ChangeBalance(ctx, u.GetID(), sum)
-		s.muRep.Unlock()
-		if err != nil {
-			return err
-		}
-		u.ChangeBalance(sum)
-	} else {
-		s.muRep.Lock()
-		err = s.rep.ChangeBalance(ctx, u.GetID(), -sum)
-		s.muRep.Unlock()
-		if err != nil {
-			return err
-		}
-		u.ChangeBalance(-sum)
+	if !checkLevels(price, position.IsBuy, r.StopLoss, r.TakeProfit) {
+		return errors.New("stop loss and take profit must be on different sides of the current price")
 	}
 
-	s.muRep.Lock()
-	err = s.rep.ClosePosition(ctx, &request.ClosePosition{
-		ID:         positionID,
-		PriceClose: price,
-	})
-	s.muRep.Unlock()
+	err = s.rep.ModifyPosition(ctx, r)
 	if err != nil {
-		if position.IsBuy {
-			u.ChangeBalance(-sum)
-			s.muRep.Lock()
-			err = s.rep.ChangeBalance(ctx, u.GetID(), -sum)
-			s.muRep.Unlock()
-			if err != nil {
-				log.Error(err)
-			} else {
-				u.ChangeBalance(sum)
-				s.muRep.Lock()
-				err = s.rep.ChangeBalance(ctx, u.GetID(), sum)
-				s.muRep.Unlock()
-				if err != nil {
-					log.Error(err)
-				}
-			}
-		}
 		return err
 	}
-	u.ClosePosition(position.SymbolID, positionID)
+	u.ModifyPosition(position.SymbolID, position.ID, r.StopLoss, r.TakeProfit)
 	return nil
 }
 
-// Close closes a position
-func (s *Service) Close(ctx context.Context, position *model.Position) error {
-	var price float32
-	if position.IsBuy {
-		s.muRep.Lock()
-		err := s.rep.ChangeBalance(ctx, position.UserID, position.AskClose * float32(position.Count))
-		s.muRep.Unlock()
+// ownPosition returns the open position if it belongs to the user
+func (s *Service) ownPosition(ctx context.Context, u *user.User, positionID int32) (*model.Position, error) {
+	position, err := s.rep.GetPosition(ctx, positionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: id %d", ErrPositionNotFound, positionID)
+		}
+		return nil, err
+	}
+	if position.UserID != u.GetID() {
+		return nil, fmt.Errorf("%w: id %d", ErrPermissionDenied, positionID)
+	}
+	if _, ok := u.GetPosition(position.SymbolID, position.ID); !ok {
+		return nil, fmt.Errorf("%w: position with id %d is closed", ErrPositionNotFound, positionID)
+	}
+	return position, nil
+}
+
+// Close closes a position for the reason and books its pnl
+func (s *Service) Close(ctx context.Context, position *model.Position, reason model.CloseReason) error {
+	price := position.AskClose
+	if !position.IsBuy {
+		price = position.BidClose
+	}
+	pnl := realizedPnl(position, price, position.Count)
+	return s.rep.InTx(ctx, func(rep repository.Repository) error {
+		err := rep.ClosePosition(ctx, &request.ClosePosition{
+			ID:         position.ID,
+			Count:      position.Count,
+			PriceClose: price,
+			Pnl:        pnl,
+			Margin:     position.Margin,
+			Reason:     reason,
+		})
 		if err != nil {
 			return err
 		}
-		price = position.AskClose
-	} else {
-		s.muRep.Lock()
-		err := s.rep.ChangeBalance(ctx, position.UserID, -(position.BidClose * float32(position.Count)))
-		s.muRep.Unlock()
+		return rep.ChangeBalance(ctx, &request.ChangeBalance{
+			UserID:     position.UserID,
+			Sum:        pnl,
+			Type:       model.EntryPositionClose,
+			PositionID: position.ID,
+		})
+	})
+}
+
+// MoveStopLoss stores the stop loss which has been moved by trailing stop
+func (s *Service) MoveStopLoss(ctx context.Context, position *model.Position) error {
+	err := s.rep.ChangeStopLoss(ctx, position.ID, position.StopLoss)
+	return err
+}
+
+// AuditMargin stores a step of the margin control
+func (s *Service) AuditMargin(ctx context.Context, event *model.MarginEvent) error {
+	return s.rep.AddMarginEvent(ctx, event)
+}
+
+// SetBalance changed balance of user. A negative change is booked as a withdrawal, a positive one as an adjustment.
+// A withdrawal can't exceed the free margin
+func (s *Service) SetBalance(ctx context.Context, userID int32, sum money.Amount) error {
+	s.muUsers.RLock()
+	u, ok := s.users[userID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+	}
+
+	if sum >= 0 {
+		err := s.rep.ChangeBalance(ctx, &request.ChangeBalance{UserID: userID, Sum: sum, Type: model.EntryAdjustment})
 		if err != nil {
 			return err
 		}
-		price = position.BidClose
+		u.ChangeBalance(sum)
+		return nil
 	}
-	s.muRep.Lock()
-	err := s.rep.ClosePosition(ctx, &request.ClosePosition{
-		ID:         position.ID,
-		PriceClose: price,
-	})
-	s.muRep.Unlock()
+	// the withdrawal is debited before it's stored, so positions opening meanwhile can't take its margin
+	if !u.Withdraw(-sum) {
+		return fmt.Errorf("%w: can't withdraw %s", ErrNotEnoughMargin, -sum)
+	}
+	err := s.rep.ChangeBalance(ctx, &request.ChangeBalance{UserID: userID, Sum: sum, Type: model.EntryWithdrawal})
 	if err != nil {
-		if position.IsBuy {
-			s.muRep.Lock()
-			err = s.rep.ChangeBalance(ctx, position.UserID, -(position.AskClose * float32(position.Count)))
-			s.muRep.Unlock()
-			if err != nil {
-				log.Error(err)
-			}
-		} else {
-			s.muRep.Lock()
-			err = s.rep.ChangeBalance(ctx, position.UserID, position.BidClose * float32(position.Count))
-			s.muRep.Unlock()
-			if err != nil {
-				log.Error(err)
-			}
-		}
+		u.ChangeBalance(-sum)
 		return err
 	}
 	return nil
 }
 
-// SetBalance changed balance of user
-func (s *Service) SetBalance(ctx context.Context, userID int32, sum float32) error {
+// GetMargin returns equity and margin of the user
+func (s *Service) GetMargin(ctx context.Context, userID int32) (*model.Margin, error) {
+	s.muUsers.RLock()
+	u, ok := s.users[userID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+	}
+	return u.GetMargin(), nil
+}
+
+// SetLeverage changes leverage which the user opens new positions with. Open positions keep their margin
+func (s *Service) SetLeverage(ctx context.Context, userID, leverage int32) error {
+	if err := checkLeverage(leverage); err != nil {
+		return err
+	}
+	s.muUsers.RLock()
+	u, ok := s.users[userID]
+	s.muUsers.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+	}
+	if err := s.rep.SetLeverage(ctx, userID, leverage); err != nil {
+		return err
+	}
+	u.SetLeverage(leverage)
+	return nil
+}
+
+// GetAccountHistory returns a page of the user's account history from the newest entry.
+// Returns cursor of the next page which is zero if this page is the last
+func (s *Service) GetAccountHistory(ctx context.Context, r *request.AccountHistory) ([]*model.AccountEntry, int32, error) {
+	limit, err := pageLimit(r.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !r.From.IsZero() && !r.To.IsZero() && !r.From.Before(r.To) {
+		return nil, 0, errors.New("the start of the range must be before the end")
+	}
+	query := *r
+	query.Limit = limit + 1 // the extra entry shows that there is the next page
+	entries, err := s.rep.GetAccountHistory(ctx, &query)
+	if err != nil {
+		return nil, 0, err
+	}
+	var next int32
+	if int32(len(entries)) > limit {
+		entries = entries[:limit]
+		next = entries[limit-1].ID
+	}
+	return entries, next, nil
+}
+
+// ListOpenPositions returns a page of the user's open positions in order of opening with the current pnl.
+// Returns cursor of the next page which is zero if this page is the last
+func (s *Service) ListOpenPositions(ctx context.Context, r *request.ListPositions) ([]*model.Position, int32, error) {
+	limit, err := pageLimit(r.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
 	s.muUsers.RLock()
-	u := s.users[userID]
+	u, ok := s.users[r.UserID]
 	s.muUsers.RUnlock()
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %d", ErrUserNotFound, r.UserID)
+	}
+
+	positions := make([]*model.Position, 0, limit+1)
+	for _, position := range u.GetPositions() {
+		if position.ID <= r.Cursor {
+			continue
+		}
+		s.muPrices.RLock()
+		price, ok := s.prices[position.SymbolID]
+		s.muPrices.RUnlock()
+		if ok {
+			position.PriceClose = price.Bid
+			if position.IsBuy {
+				position.PriceClose = price.Ask
+			}
+			position.Pnl = realizedPnl(position, position.PriceClose, position.Count)
+		}
+		positions = append(positions, position)
+		if int32(len(positions)) > limit {
+			break
+		}
+	}
+	positions, next := positionsPage(positions, limit)
+	return positions, next, nil
+}
+
+// ListClosedPositions returns a page of the user's closed positions from the last closed with the realized pnl.
+// Returns cursor of the next page which is zero if this page is the last
+func (s *Service) ListClosedPositions(ctx context.Context, r *request.ListPositions) ([]*model.Position, int32, error) {
+	limit, err := pageLimit(r.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := *r
+	query.Limit = limit + 1 // the extra position shows that there is the next page
+	positions, err := s.rep.GetClosedPositions(ctx, &query)
+	if err != nil {
+		return nil, 0, err
+	}
+	positions, next := positionsPage(positions, limit)
+	return positions, next, nil
+}
 
-	s.muRep.Lock()
-	err := s.rep.ChangeBalance(ctx, userID, sum)
-	s.muRep.Unlock()
+// AddSymbol adds tradable symbol and subscribes to its prices. Returns id of symbol
+func (s *Service) AddSymbol(ctx context.Context, r *request.AddSymbol) (int32, error) {
+	switch {
+	case r.Ticker == "" || r.Title == "":
+		return 0, errors.New("ticker and title mustn't be empty")
+	case r.LotSize <= 0:
+		return 0, errors.New("lot size must be positive")
+	case r.TickSize <= 0:
+		return 0, errors.New("tick size must be positive")
+	case len(r.Currency) != 3:
+		return 0, errors.New("currency must be a three-letter code")
+	}
+	id, err := s.rep.AddSymbol(ctx, r)
 	if err != nil {
+		return 0, err
+	}
+	s.muSymbols.Lock()
+	s.symbols[id] = &model.Symbol{
+		ID:       id,
+		Ticker:   r.Ticker,
+		Title:    r.Title,
+		Tradable: true,
+		LotSize:  r.LotSize,
+		TickSize: r.TickSize,
+		Currency: r.Currency,
+	}
+	s.muSymbols.Unlock()
+	s.publishSymbol(&model.SymbolEvent{SymbolID: id, Tradable: true})
+	return id, nil
+}
+
+// DisableSymbol forbids opening positions and placing orders by the symbol. Open positions and pending orders of
+// the symbol stay, so the broker keeps receiving its prices until they are gone
+func (s *Service) DisableSymbol(ctx context.Context, symbolID int32) error {
+	s.muSymbols.RLock()
+	symbol, ok := s.symbols[symbolID]
+	s.muSymbols.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrSymbolNotFound, symbolID)
+	}
+	if !symbol.Tradable {
+		return nil
+	}
+	if err := s.rep.SetSymbolTradable(ctx, symbolID, false); err != nil {
 		return err
 	}
-	u.ChangeBalance(sum)
+	s.muSymbols.Lock()
+	disabled := *s.symbols[symbolID]
+	disabled.Tradable = false
+	s.symbols[symbolID] = &disabled
+	s.muSymbols.Unlock()
+	s.releaseSymbol(symbolID)
 	return nil
 }
 
+// releaseSymbol unsubscribes from prices of the disabled symbol once it has no open positions and pending orders.
+// It runs when the symbol is disabled and on every price of it
+func (s *Service) releaseSymbol(symbolID int32) {
+	s.muSymbols.RLock()
+	symbol, ok := s.symbols[symbolID]
+	_, released := s.released[symbolID]
+	s.muSymbols.RUnlock()
+	if !ok || symbol.Tradable || released || s.inUse(symbolID) {
+		return
+	}
+	s.muSymbols.Lock()
+	_, released = s.released[symbolID]
+	s.released[symbolID] = struct{}{}
+	s.muSymbols.Unlock()
+	if !released {
+		s.publishSymbol(&model.SymbolEvent{SymbolID: symbolID})
+	}
+}
+
+// inUse returns true if users have open positions or pending orders by the symbol
+func (s *Service) inUse(symbolID int32) bool {
+	return s.engine.Watching(symbolID) || s.registry.count(symbolID) > 0
+}
+
+// ListSymbols returns all symbols in order of id, disabled symbols too
+func (s *Service) ListSymbols(ctx context.Context) []*model.Symbol {
+	s.muSymbols.RLock()
+	symbols := make([]*model.Symbol, 0, len(s.symbols))
+	for _, symbol := range s.symbols {
+		symbols = append(symbols, symbol)
+	}
+	s.muSymbols.RUnlock()
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].ID < symbols[j].ID
+	})
+	return symbols
+}
+
+// GetQuote returns the last price of the symbol
+func (s *Service) GetQuote(ctx context.Context, symbolID int32) (*model.Price, error) {
+	s.muSymbols.RLock()
+	_, ok := s.symbols[symbolID]
+	s.muSymbols.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrSymbolNotFound, symbolID)
+	}
+	return s.quote(symbolID)
+}
+
+// quote returns the last price of the symbol or ErrNoQuote if no price has arrived for quoteTTL
+func (s *Service) quote(symbolID int32) (*model.Price, error) {
+	s.muPrices.RLock()
+	price, ok := s.prices[symbolID]
+	received := s.received[symbolID]
+	s.muPrices.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNoQuote, symbolID)
+	}
+	if age := time.Since(received); age > s.quoteTTL {
+		return nil, fmt.Errorf("%w: the last price of symbol %d has arrived %s ago", ErrNoQuote, symbolID,
+			age.Round(time.Second))
+	}
+	return price, nil
+}
+
+// setPrice stores the last price of the symbol
+func (s *Service) setPrice(price *model.Price) {
+	s.muPrices.Lock()
+	s.prices[price.ID] = price
+	s.received[price.ID] = time.Now()
+	s.muPrices.Unlock()
+}
+
+// TradableSymbols returns ids of the symbols whose prices the broker needs: tradable ones and disabled ones which
+// still have open positions or pending orders
+func (s *Service) TradableSymbols() []int32 {
+	s.muSymbols.RLock()
+	defer s.muSymbols.RUnlock()
+	ids := make([]int32, 0, len(s.symbols))
+	for id := range s.symbols {
+		if _, released := s.released[id]; !released {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// SymbolEvents returns chan which receives added and disabled symbols to update the pricer subscription
+func (s *Service) SymbolEvents() <-chan *model.SymbolEvent {
+	return s.chSymbols
+}
+
+// publishSymbol doesn't wait for the pricer subscription, the event is dropped if nobody reads them
+func (s *Service) publishSymbol(event *model.SymbolEvent) {
+	select {
+	case s.chSymbols <- event:
+	default:
+		log.Warnf("subscription to prices of symbol %d isn't updated", event.SymbolID)
+	}
+}
+
+// EngineStats returns counters of the engine which closes positions
+func (s *Service) EngineStats() *risk.Stats {
+	return s.engine.Stats()
+}
+
 // GetBalance returns balance of user
-func (s *Service) GetBalance(ctx context.Context, userID int32) float32 {
+func (s *Service) GetBalance(ctx context.Context, userID int32) (money.Amount, error) {
 	s.muUsers.RLock()
-	u := s.users[userID]
+	u, ok := s.users[userID]
 	s.muUsers.RUnlock()
-	return u.GetBalance()
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+	}
+	return u.GetBalance(), nil
+}
+
+// tradableSymbol returns the symbol if it may be traded by the count with the prices
+func (s *Service) tradableSymbol(symbolID, count int32, prices ...money.Amount) (*model.Symbol, error) {
+	s.muSymbols.RLock()
+	symbol, ok := s.symbols[symbolID]
+	s.muSymbols.RUnlock()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: %d", ErrSymbolNotFound, symbolID)
+	case !symbol.Tradable:
+		return nil, fmt.Errorf("%w: %s", ErrSymbolNotTradable, symbol.Ticker)
+	case symbol.LotSize > 0 && (count <= 0 || count%symbol.LotSize != 0):
+		return nil, fmt.Errorf("count must be a multiple of lot size %d", symbol.LotSize)
+	}
+	if symbol.TickSize > 0 {
+		for _, price := range prices {
+			if price%symbol.TickSize != 0 {
+				return nil, fmt.Errorf("price %s must be a multiple of tick size %s", price, symbol.TickSize)
+			}
+		}
+	}
+	for _, price := range prices {
+		if _, err := price.MulChecked(count); err != nil {
+			return nil, fmt.Errorf("count %d is too large: %w", count, err)
+		}
+	}
+	return symbol, nil
 }
 
-func checkPrice(priceActual, priceWait float32, isBuy bool) bool {
+func checkPrice(priceActual, priceWait money.Amount, isBuy bool) bool {
 	if isBuy {
 		return priceWait >= priceActual
 	}
 	return priceWait <= priceActual
 }
 
-// Return true if enough money and false if not enough money
-func checkTransaction(balance, sum float32) bool {
-	return balance - sum >= 0
+// Return true if the position isn't closed right away by stop loss or take profit at the current price
+func checkLevels(price *model.Price, isBuy bool, stopLoss, takeProfit money.Amount) bool {
+	if isBuy {
+		return stopLoss < price.Ask && takeProfit > price.Ask
+	}
+	return stopLoss > price.Bid && takeProfit < price.Bid
+}
+
+// realizedPnl returns profit and loss of count shares of the position closed at the price
+func realizedPnl(position *model.Position, price money.Amount, count int32) money.Amount {
+	if position.IsBuy {
+		return (price - position.PriceOpen).Mul(count)
+	}
+	return (position.PriceOpen - price).Mul(count)
+}
+
+// checkLeverage returns error if leverage is out of the allowed range
+func checkLeverage(leverage int32) error {
+	if leverage < 1 || leverage > maxLeverage {
+		return fmt.Errorf("leverage must be between 1 and %d", maxLeverage)
+	}
+	return nil
+}
+
+// pageLimit checks the page size which the client has chosen and returns the default size instead of zero
+func pageLimit(limit int32) (int32, error) {
+	if limit < 0 || limit > maxPageSize {
+		return 0, fmt.Errorf("page size must be between 0 and %d", maxPageSize)
+	}
+	if limit == 0 {
+		return pageSize, nil
+	}
+	return limit, nil
+}
+
+// positionsPage cuts positions to the limit. Returns cursor of the next page which is zero if there are no more positions
+func positionsPage(positions []*model.Position, limit int32) ([]*model.Position, int32) {
+	if int32(len(positions)) <= limit {
+		return positions, 0
+	}
+	positions = positions[:limit]
+	return positions, positions[limit-1].ID
 }
@@ -0,0 +1,1203 @@
+package service
+
+import (
+	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	"github.com/chucky-1/broker/internal/repository"
+	"github.com/chucky-1/broker/internal/request"
+	"github.com/chucky-1/broker/internal/risk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestService returns service over the memory repository with one user and a price of the first symbol
+func newTestService(t *testing.T, ctx context.Context) (*Service, *repository.Memory, int32) {
+	rep := repository.NewMemory()
+	_, err := rep.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "SYM1",
+		Title:    "Symbol 1",
+		LotSize:  1,
+		TickSize: money.New(1),
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	engine, err := risk.NewEngine(ctx, 2, 16)
+	require.NoError(t, err)
+	s, err := NewService(ctx, rep, make(chan *model.Price), 1, model.MarginLevels{MarginCall: 100, StopOut: 50}, engine,
+		time.Minute)
+	require.NoError(t, err)
+	userID, err := s.SignUp(ctx, &request.SignUp{Login: "trader", Password: "password", Deposit: money.New(100)})
+	require.NoError(t, err)
+	s.setPrice(&model.Price{ID: 1, Bid: money.New(10), Ask: money.New(11)})
+	return s, rep, userID
+}
+
+// balance returns balance of the user which must exist
+func balance(t *testing.T, ctx context.Context, s *Service, userID int32) money.Amount {
+	sum, err := s.GetBalance(ctx, userID)
+	require.NoError(t, err)
+	return sum
+}
+
+func TestService_OpenPosition(t *testing.T) {
+	testTable := []struct {
+		name         string
+		price        money.Amount
+		count        int32
+		leverage     int32
+		orderID      int32
+		expectErr    bool
+		expectMargin money.Amount
+		expectOpen   int
+	}{
+		{
+			name:         "OK",
+			count:        3,
+			leverage:     1,
+			expectMargin: money.New(30),
+			expectOpen:   1,
+		},
+		{
+			name:      "Failed if not enough free margin",
+			count:     11,
+			leverage:  1,
+			expectErr: true,
+		},
+		{
+			name:         "OK if leverage covers the price",
+			count:        50,
+			leverage:     10,
+			expectMargin: money.New(50),
+			expectOpen:   1,
+		},
+		{
+			name:      "Rolled back if order didn't fill",
+			count:     3,
+			leverage:  1,
+			orderID:   42,
+			expectErr: true,
+		},
+		{
+			name:      "Failed if notional overflows",
+			price:     money.New(60000),
+			count:     160000000,
+			leverage:  1,
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s, rep, userID := newTestService(t, ctx)
+			require.NoError(t, s.SetLeverage(ctx, userID, testCase.leverage))
+			price := money.New(10)
+			if testCase.price != 0 {
+				price = testCase.price
+				s.setPrice(&model.Price{ID: 1, Bid: price, Ask: price + money.New(1)})
+			}
+
+			_, err := s.openPosition(ctx, &request.OpenPositionService{
+				UserID:   userID,
+				SymbolID: 1,
+				Price:    price,
+				Count:    testCase.count,
+				IsBuy:    true,
+			}, testCase.orderID)
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, money.New(100), balance(t, ctx, s, userID), "the balance changes only on close")
+			margin, err := s.GetMargin(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectMargin, margin.UsedMargin)
+			open, _ := rep.GetOpenPositions(userID)
+			assert.Len(t, open, testCase.expectOpen)
+			for _, position := range open {
+				assert.Equal(t, testCase.expectMargin, position.Margin)
+			}
+		})
+	}
+}
+
+func TestService_unknownUser(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	unknownID := userID + 1
+
+	testTable := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "get balance",
+			call: func() error {
+				_, err := s.GetBalance(ctx, unknownID)
+				return err
+			},
+		},
+		{
+			name: "set balance",
+			call: func() error {
+				return s.SetBalance(ctx, unknownID, money.New(10))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.ErrorIs(t, testCase.call(), ErrUserNotFound)
+		})
+	}
+	assert.Equal(t, money.New(100), balance(t, ctx, s, userID))
+}
+
+func TestService_reserveMargin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, rep, userID := newTestService(t, ctx)
+	s.setPrice(&model.Price{ID: 1, Bid: money.New(10), Ask: money.New(10)}) // positions open without pnl
+
+	const attempts = 20
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		go func() {
+			_, err := s.OpenPosition(ctx, &request.OpenPositionService{
+				UserID:   userID,
+				SymbolID: 1,
+				Price:    money.New(10),
+				Count:    1,
+				IsBuy:    true,
+			})
+			errs <- err
+		}()
+	}
+	var opened int
+	for i := 0; i < attempts; i++ {
+		if err := <-errs; err != nil {
+			assert.ErrorIs(t, err, ErrNotEnoughMargin)
+		} else {
+			opened++
+		}
+	}
+
+	assert.Equal(t, 10, opened, "the balance covers margin of 10 positions")
+	margin, err := s.GetMargin(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(100), margin.UsedMargin)
+	open, _ := rep.GetOpenPositions(userID)
+	assert.Len(t, open, opened)
+}
+
+func TestService_SetBalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	// the position reserves margin 20 and has pnl 2
+	_, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    2,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name          string
+		sum           money.Amount
+		expectErr     bool
+		expectType    model.EntryType
+		expectBalance money.Amount
+	}{
+		{
+			name:          "Adjustment if sum is positive",
+			sum:           money.New(20),
+			expectType:    model.EntryAdjustment,
+			expectBalance: money.New(120),
+		},
+		{
+			name:          "Withdrawal if sum is negative",
+			sum:           -money.New(50),
+			expectType:    model.EntryWithdrawal,
+			expectBalance: money.New(70),
+		},
+		{
+			name:          "Failed if withdrawal is above free margin",
+			sum:           -money.New(53),
+			expectErr:     true,
+			expectBalance: money.New(70),
+		},
+		{
+			name:          "Withdrawal of the whole free margin",
+			sum:           -money.New(52),
+			expectType:    model.EntryWithdrawal,
+			expectBalance: money.New(18),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := s.SetBalance(ctx, userID, testCase.sum)
+			assert.Equal(t, testCase.expectBalance, balance(t, ctx, s, userID))
+			entries, _, historyErr := s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID, Limit: 1})
+			require.NoError(t, historyErr)
+			require.Len(t, entries, 1)
+			if testCase.expectErr {
+				assert.ErrorIs(t, err, ErrNotEnoughMargin)
+				assert.NotEqual(t, testCase.sum, entries[0].Amount, "the failed withdrawal isn't booked")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectType, entries[0].Type)
+			assert.Equal(t, testCase.sum, entries[0].Amount)
+		})
+	}
+}
+
+func TestService_ClosePosition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, rep, userID := newTestService(t, ctx)
+	positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    4,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+
+	// the cases run one by one on the same position, zero count closes the rest
+	testTable := []struct {
+		name          string
+		count         int32
+		expectErr     bool
+		expectClosed  int32 // count of the closed position
+		expectCount   int32 // count of the position which stays open
+		expectMargin  money.Amount
+		expectBalance money.Amount
+	}{
+		{
+			name:          "Failed if count is negative",
+			count:         -1,
+			expectErr:     true,
+			expectCount:   4,
+			expectMargin:  money.New(40),
+			expectBalance: money.New(100),
+		},
+		{
+			name:          "Failed if count is above count of the position",
+			count:         5,
+			expectErr:     true,
+			expectCount:   4,
+			expectMargin:  money.New(40),
+			expectBalance: money.New(100),
+		},
+		{
+			name:          "OK if one share",
+			count:         1,
+			expectClosed:  1,
+			expectCount:   3,
+			expectMargin:  money.New(30),
+			expectBalance: money.New(101),
+		},
+		{
+			name:          "OK if the rest but one share",
+			count:         2,
+			expectClosed:  2,
+			expectCount:   1,
+			expectMargin:  money.New(10),
+			expectBalance: money.New(103),
+		},
+		{
+			name:          "OK if the rest",
+			expectClosed:  1,
+			expectBalance: money.New(104),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			closedID, err := s.ClosePosition(ctx, &request.ClosePositionService{
+				UserID:     userID,
+				PositionID: positionID,
+				Count:      testCase.count,
+			})
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				if testCase.count == 0 {
+					assert.Equal(t, positionID, closedID, "the rest is closed as the position itself")
+				} else {
+					assert.NotEqual(t, positionID, closedID, "the closed part gets its own id")
+				}
+				closed, err := rep.GetPosition(ctx, closedID)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectClosed, closed.Count)
+				assert.Equal(t, money.New(10).Mul(testCase.expectClosed), closed.Margin)
+				assert.Equal(t, money.New(1).Mul(testCase.expectClosed), closed.Pnl)
+				assert.False(t, closed.TimeClose.IsZero())
+
+				entries, _, err := s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID, Limit: 1})
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+				assert.Equal(t, model.EntryPositionClose, entries[0].Type)
+				assert.Equal(t, closedID, entries[0].PositionID, "pnl is booked to the closed part")
+				assert.Equal(t, closed.Pnl, entries[0].Amount)
+			}
+
+			position, ok := s.users[userID].GetPosition(1, positionID)
+			if testCase.expectCount == 0 {
+				assert.False(t, ok)
+				open, _ := rep.GetOpenPositions(userID)
+				assert.Empty(t, open)
+			} else {
+				require.True(t, ok, "the rest of the position stays open")
+				stored, err := rep.GetPosition(ctx, positionID)
+				require.NoError(t, err)
+				for _, p := range []*model.Position{stored, position} {
+					assert.Equal(t, testCase.expectCount, p.Count)
+					assert.Equal(t, testCase.expectMargin, p.Margin)
+				}
+			}
+			margin, err := s.GetMargin(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectMargin, margin.UsedMargin)
+			assert.Equal(t, testCase.expectBalance, margin.Balance)
+			if testCase.expectCount > 0 {
+				equity := testCase.expectBalance + money.New(int64(testCase.expectCount))
+				assert.Equal(t, equity, margin.Equity)
+				assert.InDelta(t, float64(equity)/float64(testCase.expectMargin)*100, margin.MarginLevel, 1e-9)
+			} else {
+				assert.Equal(t, margin.Balance, margin.FreeMargin)
+			}
+		})
+	}
+}
+
+func TestService_ModifyPosition(t *testing.T) {
+	testTable := []struct {
+		name       string
+		isBuy      bool
+		stopLoss   money.Amount
+		takeProfit money.Amount
+		expectErr  bool
+	}{
+		{
+			name:       "OK if buy",
+			isBuy:      true,
+			stopLoss:   money.New(5),
+			takeProfit: money.New(20),
+		},
+		{
+			name:       "Failed if stop loss of buy is above the price",
+			isBuy:      true,
+			stopLoss:   money.New(12),
+			takeProfit: money.New(20),
+			expectErr:  true,
+		},
+		{
+			name:       "Failed if take profit of buy is below the price",
+			isBuy:      true,
+			stopLoss:   money.New(5),
+			takeProfit: money.New(8),
+			expectErr:  true,
+		},
+		{
+			name:       "OK if sell",
+			stopLoss:   money.New(20),
+			takeProfit: money.New(5),
+		},
+		{
+			name:       "Failed if stop loss of sell is below the price",
+			stopLoss:   money.New(8),
+			takeProfit: money.New(5),
+			expectErr:  true,
+		},
+		{
+			name:       "Failed if take profit of sell is above the price",
+			stopLoss:   money.New(20),
+			takeProfit: money.New(12),
+			expectErr:  true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s, rep, userID := newTestService(t, ctx)
+			positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+				UserID:   userID,
+				SymbolID: 1,
+				Price:    money.New(11),
+				Count:    1,
+				IsBuy:    testCase.isBuy,
+			})
+			require.NoError(t, err)
+
+			err = s.ModifyPosition(ctx, &request.ModifyPosition{
+				UserID:     userID,
+				PositionID: positionID,
+				StopLoss:   testCase.stopLoss,
+				TakeProfit: testCase.takeProfit,
+			})
+			expectStopLoss, expectTakeProfit := testCase.stopLoss, testCase.takeProfit
+			if testCase.expectErr {
+				assert.Error(t, err)
+				expectStopLoss, expectTakeProfit = 0, 0
+			} else {
+				assert.NoError(t, err)
+			}
+
+			stored, err := rep.GetPosition(ctx, positionID)
+			require.NoError(t, err)
+			position, ok := s.users[userID].GetPosition(1, positionID)
+			require.True(t, ok)
+			for _, p := range []*model.Position{stored, position} {
+				assert.Equal(t, expectStopLoss, p.StopLoss)
+				assert.Equal(t, expectTakeProfit, p.TakeProfit)
+			}
+		})
+	}
+}
+
+func TestService_SubscribePrices(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, _ := newTestService(t, ctx)
+
+	testTable := []struct {
+		name      string
+		symbolIDs []int32
+	}{
+		{
+			name: "Failed if no symbols",
+		},
+		{
+			name:      "Failed if symbol doesn't exist",
+			symbolIDs: []int32{1, 42},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := s.SubscribePrices(ctx, testCase.symbolIDs)
+			assert.Error(t, err)
+		})
+	}
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	ch, err := s.SubscribePrices(subCtx, []int32{1})
+	require.NoError(t, err)
+	const dropped = 10
+	for i := 1; i <= priceBuffer+dropped; i++ {
+		s.streams.publish(&model.Price{ID: 1, Bid: money.New(int64(i)), Ask: money.New(int64(i))})
+		s.streams.publish(&model.Price{ID: 2, Bid: money.New(int64(i)), Ask: money.New(int64(i))})
+	}
+	require.Len(t, ch, priceBuffer, "the slow subscriber keeps a full buffer")
+	for i := dropped + 1; i <= priceBuffer+dropped; i++ {
+		price := <-ch
+		assert.Equal(t, int32(1), price.ID, "prices of other symbols don't reach the subscriber")
+		assert.Equal(t, money.New(int64(i)), price.Bid, "the oldest prices are dropped in favor of the latest")
+	}
+
+	subCancel()
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-ch:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond, "chan is closed when ctx is done")
+	assert.Zero(t, s.streams.count(1), "the closed stream doesn't wait for prices")
+}
+
+func TestService_ownPosition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	otherID, err := s.SignUp(ctx, &request.SignUp{Login: "other", Password: "password", Deposit: money.New(100)})
+	require.NoError(t, err)
+	open := func(userID int32) int32 {
+		positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+			UserID:   userID,
+			SymbolID: 1,
+			Price:    money.New(10),
+			Count:    1,
+			IsBuy:    true,
+		})
+		require.NoError(t, err)
+		return positionID
+	}
+	otherPositionID := open(otherID)
+	closedID := open(userID)
+	_, err = s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: closedID})
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name       string
+		positionID int32
+		expectErr  error
+	}{
+		{
+			name:       "Not found if position doesn't exist",
+			positionID: 42,
+			expectErr:  ErrPositionNotFound,
+		},
+		{
+			name:       "Not found if position is closed",
+			positionID: closedID,
+			expectErr:  ErrPositionNotFound,
+		},
+		{
+			name:       "Permission denied if position belongs to another user",
+			positionID: otherPositionID,
+			expectErr:  ErrPermissionDenied,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: testCase.positionID})
+			assert.ErrorIs(t, err, testCase.expectErr)
+			err = s.ModifyPosition(ctx, &request.ModifyPosition{
+				UserID:     userID,
+				PositionID: testCase.positionID,
+				StopLoss:   money.New(5),
+				TakeProfit: money.New(20),
+			})
+			assert.ErrorIs(t, err, testCase.expectErr)
+		})
+	}
+
+	position, ok := s.users[otherID].GetPosition(1, otherPositionID)
+	require.True(t, ok, "the position of another user stays open")
+	assert.Zero(t, position.StopLoss)
+}
+
+func TestService_GetAccountHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+
+	positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    2,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+	_, err = s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: positionID})
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name        string
+		history     *request.AccountHistory
+		expectErr   bool
+		expectTypes []model.EntryType
+		expectNext  bool
+	}{
+		{
+			name:        "OK if all entries",
+			history:     &request.AccountHistory{UserID: userID},
+			expectTypes: []model.EntryType{model.EntryPositionClose, model.EntryPositionOpen, model.EntryDeposit},
+		},
+		{
+			name:        "OK if page is less than history",
+			history:     &request.AccountHistory{UserID: userID, Limit: 1},
+			expectTypes: []model.EntryType{model.EntryPositionClose},
+			expectNext:  true,
+		},
+		{
+			name: "OK if filtered by type",
+			history: &request.AccountHistory{UserID: userID,
+				Types: []model.EntryType{model.EntryDeposit, model.EntryAdjustment}},
+			expectTypes: []model.EntryType{model.EntryDeposit},
+		},
+		{
+			name:        "OK if filtered by symbol",
+			history:     &request.AccountHistory{UserID: userID, SymbolID: 2},
+			expectTypes: []model.EntryType{},
+		},
+		{
+			name:      "Failed if page is too large",
+			history:   &request.AccountHistory{UserID: userID, Limit: maxPageSize + 1},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			entries, next, err := s.GetAccountHistory(ctx, testCase.history)
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			types := make([]model.EntryType, 0, len(entries))
+			for _, e := range entries {
+				types = append(types, e.Type)
+			}
+			assert.Equal(t, testCase.expectTypes, types)
+			assert.Equal(t, testCase.expectNext, next != 0)
+		})
+	}
+
+	entries, _, err := s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID,
+		Types: []model.EntryType{model.EntryPositionClose}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, money.New(2), entries[0].Pnl)
+	assert.Equal(t, "Symbol 1", entries[0].SymbolTitle)
+
+	_, next, err := s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID, Limit: 1})
+	require.NoError(t, err)
+	entries, next, err = s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID, Limit: 1, Cursor: next})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, model.EntryPositionOpen, entries[0].Type)
+	assert.Equal(t, positionID, entries[0].PositionID)
+	assert.Equal(t, "Symbol 1", entries[0].SymbolTitle)
+	assert.Zero(t, entries[0].Amount)
+	entries, next, err = s.GetAccountHistory(ctx, &request.AccountHistory{UserID: userID, Limit: 1, Cursor: next})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, model.EntryDeposit, entries[0].Type)
+	assert.Zero(t, next)
+}
+
+func TestService_ListPositions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+
+	var ids []int32
+	for i := 0; i < 3; i++ {
+		id, err := s.OpenPosition(ctx, &request.OpenPositionService{
+			UserID:   userID,
+			SymbolID: 1,
+			Price:    money.New(10),
+			Count:    1,
+			IsBuy:    true,
+		})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	for _, id := range ids[:2] {
+		_, err := s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: id})
+		require.NoError(t, err)
+	}
+	_, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    1,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name       string
+		list       func(context.Context, *request.ListPositions) ([]*model.Position, int32, error)
+		limit      int32
+		expectIDs  [][]int32
+		expectPnl  money.Amount
+		expectOpen bool
+	}{
+		{
+			name:       "Open positions in order of opening",
+			list:       s.ListOpenPositions,
+			limit:      1,
+			expectIDs:  [][]int32{{ids[2]}, {ids[2] + 1}},
+			expectPnl:  money.New(1),
+			expectOpen: true,
+		},
+		{
+			name:      "Closed positions from the last closed",
+			list:      s.ListClosedPositions,
+			limit:     1,
+			expectIDs: [][]int32{{ids[1]}, {ids[0]}},
+			expectPnl: money.New(1),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			var cursor int32
+			for page, expectIDs := range testCase.expectIDs {
+				positions, next, err := testCase.list(ctx, &request.ListPositions{UserID: userID, Cursor: cursor, Limit: testCase.limit})
+				require.NoError(t, err)
+				got := make([]int32, 0, len(positions))
+				for _, p := range positions {
+					got = append(got, p.ID)
+					assert.Equal(t, testCase.expectPnl, p.Pnl)
+					assert.Equal(t, "Symbol 1", p.SymbolTitle)
+					assert.Equal(t, testCase.expectOpen, p.TimeClose.IsZero())
+				}
+				assert.Equal(t, expectIDs, got)
+				assert.Equal(t, page == len(testCase.expectIDs)-1, next == 0)
+				cursor = next
+			}
+		})
+	}
+}
+
+func TestService_tradableSymbol(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, _ := newTestService(t, ctx)
+	lotID, err := s.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "LOT",
+		Title:    "Symbol with lots",
+		LotSize:  10,
+		TickSize: money.New(1) / 100,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	disabledID, err := s.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "OFF",
+		Title:    "Disabled symbol",
+		LotSize:  1,
+		TickSize: money.New(1),
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.DisableSymbol(ctx, disabledID))
+	assert.Equal(t, []int32{1, lotID}, s.TradableSymbols())
+
+	testTable := []struct {
+		name      string
+		symbolID  int32
+		count     int32
+		price     money.Amount
+		expectErr bool
+	}{
+		{
+			name:     "OK",
+			symbolID: lotID,
+			count:    20,
+			price:    money.New(5) + money.New(1)/100,
+		},
+		{
+			name:      "Failed if symbol doesn't exist",
+			symbolID:  42,
+			count:     1,
+			expectErr: true,
+		},
+		{
+			name:      "Failed if symbol is disabled",
+			symbolID:  disabledID,
+			count:     1,
+			expectErr: true,
+		},
+		{
+			name:      "Failed if count isn't a multiple of lot size",
+			symbolID:  lotID,
+			count:     15,
+			expectErr: true,
+		},
+		{
+			name:      "Failed if price isn't a multiple of tick size",
+			symbolID:  lotID,
+			count:     10,
+			price:     money.New(1) / 1000,
+			expectErr: true,
+		},
+		{
+			name:      "Failed if notional overflows",
+			symbolID:  lotID,
+			count:     160000000,
+			price:     money.New(60000),
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := s.tradableSymbol(testCase.symbolID, testCase.count, testCase.price)
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	events := []*model.SymbolEvent{<-s.SymbolEvents(), <-s.SymbolEvents(), <-s.SymbolEvents()}
+	assert.Equal(t, []*model.SymbolEvent{
+		{SymbolID: lotID, Tradable: true},
+		{SymbolID: disabledID, Tradable: true},
+		{SymbolID: disabledID},
+	}, events)
+}
+
+func TestService_DisableSymbol(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:     userID,
+		SymbolID:   1,
+		Price:      money.New(10),
+		Count:      1,
+		TakeProfit: money.New(20),
+		IsBuy:      true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DisableSymbol(ctx, 1))
+	_, err = s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    1,
+		IsBuy:    true,
+	})
+	assert.ErrorIs(t, err, ErrSymbolNotTradable)
+	assert.Equal(t, []int32{1}, s.TradableSymbols(), "the open position still needs prices")
+	assert.Empty(t, s.SymbolEvents())
+
+	s.chPrice <- &model.Price{ID: 1, Bid: money.New(10), Ask: money.New(12)}
+	require.Eventually(t, func() bool {
+		margin, err := s.GetMargin(ctx, userID)
+		return err == nil && margin.Equity == money.New(102)
+	}, time.Second, time.Millisecond, "the open position gets prices of the disabled symbol")
+	_, err = s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: positionID})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1}, s.TradableSymbols(), "the symbol is released by its next price")
+
+	s.chPrice <- &model.Price{ID: 1, Bid: money.New(10), Ask: money.New(12)}
+	assert.Equal(t, &model.SymbolEvent{SymbolID: 1}, <-s.SymbolEvents())
+	assert.Empty(t, s.TradableSymbols())
+}
+
+func TestService_GetQuote(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, _ := newTestService(t, ctx)
+	closedID, err := s.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "SYM2",
+		Title:    "Symbol 2",
+		LotSize:  1,
+		TickSize: money.New(1),
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	staleID, err := s.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "SYM3",
+		Title:    "Symbol 3",
+		LotSize:  1,
+		TickSize: money.New(1),
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	s.setPrice(&model.Price{ID: staleID, Bid: money.New(10), Ask: money.New(11)})
+	s.received[staleID] = time.Now().Add(-2 * time.Minute)
+
+	testTable := []struct {
+		name      string
+		symbolID  int32
+		expectErr error
+	}{
+		{
+			name:     "OK",
+			symbolID: 1,
+		},
+		{
+			name:      "Failed if no price has arrived",
+			symbolID:  closedID,
+			expectErr: ErrNoQuote,
+		},
+		{
+			name:      "Failed if the last price is older than quote ttl",
+			symbolID:  staleID,
+			expectErr: ErrNoQuote,
+		},
+		{
+			name:      "Failed if symbol doesn't exist",
+			symbolID:  42,
+			expectErr: ErrSymbolNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			price, err := s.GetQuote(ctx, testCase.symbolID)
+			if testCase.expectErr != nil {
+				assert.ErrorIs(t, err, testCase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, money.New(10), price.Bid)
+		})
+	}
+
+	symbols := s.ListSymbols(ctx)
+	require.Len(t, symbols, 3)
+	assert.Equal(t, "SYM1", symbols[0].Ticker)
+	assert.Equal(t, closedID, symbols[1].ID)
+}
+
+func TestService_noQuote(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	noQuoteID, err := s.AddSymbol(ctx, &request.AddSymbol{
+		Ticker:   "SYM2",
+		Title:    "Symbol 2",
+		LotSize:  1,
+		TickSize: money.New(1),
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    1,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+	s.muPrices.Lock()
+	delete(s.prices, 1) // the broker has restarted and the pricer hasn't sent the price yet
+	s.muPrices.Unlock()
+
+	testTable := []struct {
+		name      string
+		call      func() error
+		expectErr error
+	}{
+		{
+			name: "Open position by unknown symbol",
+			call: func() error {
+				_, err := s.OpenPosition(ctx, &request.OpenPositionService{UserID: userID, SymbolID: 42, Count: 1, IsBuy: true})
+				return err
+			},
+			expectErr: ErrSymbolNotFound,
+		},
+		{
+			name: "Open position by symbol without price",
+			call: func() error {
+				_, err := s.OpenPosition(ctx, &request.OpenPositionService{UserID: userID, SymbolID: noQuoteID, Count: 1})
+				return err
+			},
+			expectErr: ErrNoQuote,
+		},
+		{
+			name: "Close position without price",
+			call: func() error {
+				_, err := s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: positionID})
+				return err
+			},
+			expectErr: ErrNoQuote,
+		},
+		{
+			name: "Modify position without price",
+			call: func() error {
+				return s.ModifyPosition(ctx, &request.ModifyPosition{UserID: userID, PositionID: positionID})
+			},
+			expectErr: ErrNoQuote,
+		},
+		{
+			name: "Place order by unknown symbol",
+			call: func() error {
+				_, err := s.PlaceOrder(ctx, &request.PlaceOrder{UserID: userID, SymbolID: 42, Count: 1})
+				return err
+			},
+			expectErr: ErrSymbolNotFound,
+		},
+		{
+			name: "Subscribe to unknown symbol",
+			call: func() error {
+				_, err := s.SubscribePrices(ctx, []int32{42})
+				return err
+			},
+			expectErr: ErrSymbolNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.ErrorIs(t, testCase.call(), testCase.expectErr)
+			})
+		})
+	}
+
+	assert.Equal(t, money.New(100), balance(t, ctx, s, userID))
+	positions, _, err := s.ListOpenPositions(ctx, &request.ListPositions{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Zero(t, positions[0].Pnl, "pnl is unknown without price")
+}
+
+func TestService_closeByPrice(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, rep, userID := newTestService(t, ctx)
+
+	_, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:     userID,
+		SymbolID:   1,
+		Price:      money.New(10),
+		Count:      1,
+		StopLoss:   money.New(9),
+		TakeProfit: money.New(20),
+		IsBuy:      true,
+	})
+	require.NoError(t, err)
+
+	s.chPrice <- &model.Price{ID: 1, Bid: money.New(8), Ask: money.New(8)}
+	require.Eventually(t, func() bool {
+		open, _ := rep.GetOpenPositions(userID)
+		return len(open) == 0 && s.EngineStats().Positions == 0
+	}, time.Second, time.Millisecond, "the engine closes the position by stop loss")
+	assert.Equal(t, money.New(98), balance(t, ctx, s, userID))
+	closed, _, err := s.ListClosedPositions(ctx, &request.ListPositions{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, model.CloseStopLoss, closed[0].CloseReason)
+}
+
+func TestService_registry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _, userID := newTestService(t, ctx)
+	assert.Zero(t, s.registry.count(1), "the user without pending orders doesn't wait for prices")
+
+	positionID, err := s.OpenPosition(ctx, &request.OpenPositionService{
+		UserID:   userID,
+		SymbolID: 1,
+		Price:    money.New(10),
+		Count:    1,
+		IsBuy:    true,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, s.registry.count(1), "open positions get prices from the risk engine")
+
+	_, err = s.PlaceOrder(ctx, &request.PlaceOrder{
+		UserID:     userID,
+		SymbolID:   1,
+		Type:       model.OrderLimit,
+		Price:      money.New(5),
+		Count:      1,
+		TakeProfit: money.New(20),
+		IsBuy:      true,
+	})
+	require.NoError(t, err)
+	_, err = s.ClosePosition(ctx, &request.ClosePositionService{UserID: userID, PositionID: positionID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.registry.count(1), "the pending order waits for prices")
+
+	s.chPrice <- &model.Price{ID: 1, Bid: money.New(5), Ask: money.New(6)}
+	require.Eventually(t, func() bool {
+		positions, _, err := s.ListOpenPositions(ctx, &request.ListPositions{UserID: userID})
+		return err == nil && len(positions) == 1
+	}, time.Second, time.Millisecond, "the order is executed by the price")
+	require.Eventually(t, func() bool {
+		return s.registry.count(1) == 0
+	}, time.Second, time.Millisecond, "the user without pending orders doesn't wait for prices")
+}
+
+func TestService_Execute(t *testing.T) {
+	testTable := []struct {
+		name        string
+		orderType   model.OrderType
+		bid         money.Amount
+		expectPrice money.Amount
+	}{
+		{
+			name:        "Limit order is filled at its price if the price which has triggered it is gone",
+			orderType:   model.OrderLimit,
+			bid:         money.New(6),
+			expectPrice: money.New(5),
+		},
+		{
+			name:        "Limit order is filled at the market price if it is better",
+			orderType:   model.OrderLimit,
+			bid:         money.New(4),
+			expectPrice: money.New(4),
+		},
+		{
+			name:        "Stop order is filled at the market price",
+			orderType:   model.OrderStop,
+			bid:         money.New(6),
+			expectPrice: money.New(6),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s, rep, userID := newTestService(t, ctx)
+			orderID, err := s.PlaceOrder(ctx, &request.PlaceOrder{
+				UserID:     userID,
+				SymbolID:   1,
+				Type:       testCase.orderType,
+				Price:      money.New(5),
+				Count:      1,
+				TakeProfit: money.New(20),
+				IsBuy:      true,
+			})
+			require.NoError(t, err)
+			orders, err := rep.GetPendingOrders(userID)
+			require.NoError(t, err)
+
+			s.setPrice(&model.Price{ID: 1, Bid: testCase.bid, Ask: testCase.bid + money.New(1)})
+			require.NoError(t, s.Execute(ctx, orders[orderID]))
+			positions, _, err := s.ListOpenPositions(ctx, &request.ListPositions{UserID: userID})
+			require.NoError(t, err)
+			require.Len(t, positions, 1)
+			assert.Equal(t, testCase.expectPrice, positions[0].PriceOpen)
+		})
+	}
+}
+
+func TestService_rejectOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, rep, userID := newTestService(t, ctx)
+	events, err := s.StreamPositions(ctx, userID)
+	require.NoError(t, err)
+	orderID, err := s.PlaceOrder(ctx, &request.PlaceOrder{
+		UserID:     userID,
+		SymbolID:   1,
+		Type:       model.OrderLimit,
+		Price:      money.New(5),
+		Count:      30,
+		TakeProfit: money.New(20),
+		IsBuy:      true,
+	})
+	require.NoError(t, err)
+
+	s.chPrice <- &model.Price{ID: 1, Bid: money.New(5), Ask: money.New(6)}
+	event := <-events
+	assert.True(t, event.OrderRejected, "the margin of the order is above the balance")
+	assert.Equal(t, orderID, event.OrderID)
+	assert.Contains(t, event.RejectReason, ErrNotEnoughMargin.Error())
+
+	orders, err := rep.GetPendingOrders(userID)
+	require.NoError(t, err)
+	assert.Empty(t, orders, "the rejected order isn't pending")
+	require.Eventually(t, func() bool {
+		return s.registry.count(1) == 0
+	}, time.Second, time.Millisecond, "the rejected order doesn't wait for prices")
+	positions, _, err := s.ListOpenPositions(ctx, &request.ListPositions{UserID: userID})
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+}
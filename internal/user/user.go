@@ -2,76 +2,242 @@
 package user
 
 import (
+	"github.com/chucky-1/broker/internal/feed"
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
 	"github.com/chucky-1/broker/internal/request"
 	log "github.com/sirupsen/logrus"
 
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 )
 
+// eventBuffer is a count of events which a slow listener may not read before the oldest of them are dropped
+const eventBuffer = 64
+
 // User keeps state each user
 type User struct {
-	id        int32
-	muBalance sync.RWMutex
-	balance   float32
-	chPrice   chan *model.Price
-	positions *sync.Map // map[symbolID]map[position.ID]*position
-	closer    request.PositionCloser
+	id          int32
+	muBalance   sync.RWMutex // is taken after muPositions when both are held
+	balance     money.Amount
+	leverage    int32
+	usedMargin  money.Amount // sum of margin of open positions
+	levels      model.MarginLevels
+	warned      bool // the user has been warned about margin call, it is used only by ControlMargin
+	muTicks     sync.Mutex
+	ticks       map[int32]*tickRange // map[symbolID]prices which have arrived since the orders were checked
+	wake        chan struct{}        // tells the goroutine of the user that prices have arrived
+	muPositions sync.RWMutex // guards maps of positions and their fields which change while they are open
+	positions   *sync.Map    // map[symbolID]map[position.ID]*position
+	muOrders    sync.Mutex   // guards maps of orders
+	orders      *sync.Map    // map[symbolID]map[order.ID]*order
+	closer      request.PositionCloser
+	executor    request.OrderExecutor
+	mover       request.StopLossMover
+	auditor     request.MarginAuditor
+	registry    request.PriceRegistry
+	muSubscribe sync.Mutex // serializes changes of the subscriptions to prices
+	muListeners sync.RWMutex
+	listeners   map[chan *model.PositionEvent]struct{}
 }
 
 // NewUser is constructor
-func NewUser(ctx context.Context, id int32, balance float32, positions *sync.Map, closer request.PositionCloser) (*User, error) {
+func NewUser(ctx context.Context, id int32, balance money.Amount, leverage int32, levels model.MarginLevels,
+	positions, orders *sync.Map, closer request.PositionCloser, executor request.OrderExecutor,
+	mover request.StopLossMover, auditor request.MarginAuditor, registry request.PriceRegistry) (*User, error) {
+	if leverage <= 0 {
+		return nil, fmt.Errorf("leverage of user %d must be positive", id)
+	}
 	u := User{
 		id:        id,
 		balance:   balance,
-		chPrice:   make(chan *model.Price),
+		leverage:  leverage,
+		levels:    levels,
+		ticks:     make(map[int32]*tickRange),
+		wake:      make(chan struct{}, 1),
 		positions: positions,
+		orders:    orders,
 		closer:    closer,
+		executor:  executor,
+		mover:     mover,
+		auditor:   auditor,
+		registry:  registry,
+		listeners: make(map[chan *model.PositionEvent]struct{}),
 	}
+	positions.Range(func(symbolID, m interface{}) bool {
+		for _, position := range m.(map[int32]*model.Position) {
+			u.usedMargin += position.Margin
+		}
+		return true
+	})
+	orders.Range(func(symbolID, _ interface{}) bool {
+		u.subscribe(symbolID.(int32))
+		return true
+	})
 	go func(ctx context.Context) {
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case price := <-u.chPrice:
-				p, ok := u.positions.Load(price.ID)
-				if !ok {
-					continue
-				}
-				pos := p.(map[int32]*model.Position)
-				for _, position := range pos{
-					position.BidClose = price.Bid
-					position.AskClose = price.Ask
-					pn := pnl(position)
-					log.Infof("pnl for position %d is %f", position.ID, pn)
-					if stopLoss(position) {
-						err := u.close(ctx, position)
-						if err != nil {
-							log.Error(err)
-						}
-					}
-					if takeProfit(position) {
-						err := u.close(ctx, position)
-						if err != nil {
-							log.Error(err)
-						}
-					}
-					if u.marginCall(position) {
-						err := u.close(ctx, position)
-						if err != nil {
-							log.Error(err)
-						}
-					}
-				}
+			case <-u.wake:
+				u.checkOrders(ctx)
 			}
 		}
 	}(ctx)
 	return &u, nil
 }
 
-// OpenPosition appends position
+// UpdatePosition sets the price to the open position, moves its trailing stop and sends its pnl to listeners.
+// Returns true if the stop loss has moved, and the reason if the position must be closed by stop loss or take profit
+func (u *User) UpdatePosition(position *model.Position, price *model.Price) (moved bool, reason model.CloseReason, ok bool) {
+	u.muPositions.Lock()
+	position.BidClose = price.Bid
+	position.AskClose = price.Ask
+	moved = trailStopLoss(position)
+	current := *position
+	u.muPositions.Unlock()
+
+	u.publish(&model.PositionEvent{
+		PositionID: current.ID,
+		SymbolID:   current.SymbolID,
+		PriceClose: priceClose(&current),
+		Pnl:        pnl(&current),
+	})
+	switch {
+	case stopLoss(&current):
+		return moved, model.CloseStopLoss, true
+	case takeProfit(&current):
+		return moved, model.CloseTakeProfit, true
+	default:
+		return moved, 0, false
+	}
+}
+
+// MoveStopLoss stores the stop loss which trailing stop has moved. The position is read when the store runs, so the
+// latest stop loss is stored
+func (u *User) MoveStopLoss(ctx context.Context, position *model.Position) error {
+	u.muPositions.RLock()
+	current := *position
+	u.muPositions.RUnlock()
+	return u.mover.MoveStopLoss(ctx, &current)
+}
+
+// Close closes the open position for the reason
+func (u *User) Close(ctx context.Context, position *model.Position, reason model.CloseReason) error {
+	return u.close(ctx, position, reason)
+}
+
+// ControlMargin warns the user once the margin level falls below the margin-call level and closes positions
+// from the worst loss while it is below the stop-out level. Every step is audited. Returns the closed positions.
+// It mustn't run concurrently for the same user
+func (u *User) ControlMargin(ctx context.Context) []*model.Position {
+	margin := u.GetMargin()
+	if margin.UsedMargin <= 0 || margin.MarginLevel >= u.levels.MarginCall {
+		u.warned = false
+		return nil
+	}
+	if !u.warned {
+		u.warned = true
+		log.Warnf("margin level of user %d is %.2f%%", u.id, margin.MarginLevel)
+		u.audit(ctx, model.MarginCallWarning, 0, margin)
+		u.publish(&model.PositionEvent{MarginCall: true, MarginLevel: margin.MarginLevel})
+	}
+	var closed []*model.Position
+	for margin.UsedMargin > 0 && margin.MarginLevel < u.levels.StopOut {
+		position, ok := u.worstPosition()
+		if !ok {
+			break
+		}
+		if err := u.close(ctx, position, model.CloseMarginCall); err != nil {
+			log.Error(err)
+			break
+		}
+		log.Warnf("position %d of user %d is closed by stop out at margin level %.2f%%", position.ID, u.id,
+			margin.MarginLevel)
+		u.audit(ctx, model.MarginStopOut, position.ID, margin)
+		closed = append(closed, position)
+		margin = u.GetMargin()
+	}
+	return closed
+}
+
+// worstPosition returns the open position with the least pnl. Positions without a price can't be closed
+func (u *User) worstPosition() (*model.Position, bool) {
+	u.muPositions.RLock()
+	defer u.muPositions.RUnlock()
+	var worst *model.Position
+	u.positions.Range(func(_, m interface{}) bool {
+		for _, position := range m.(map[int32]*model.Position) {
+			if !quoted(position) {
+				continue
+			}
+			if worst == nil || pnl(position) < pnl(worst) || pnl(position) == pnl(worst) && position.ID < worst.ID {
+				worst = position
+			}
+		}
+		return true
+	})
+	return worst, worst != nil
+}
+
+// audit stores the step of the margin control with the margin which has caused it
+func (u *User) audit(ctx context.Context, eventType model.MarginEventType, positionID int32, margin *model.Margin) {
+	err := u.auditor.AuditMargin(ctx, &model.MarginEvent{
+		UserID:      u.id,
+		Type:        eventType,
+		PositionID:  positionID,
+		Equity:      margin.Equity,
+		UsedMargin:  margin.UsedMargin,
+		MarginLevel: margin.MarginLevel,
+		Time:        time.Now(),
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// ReserveMargin reserves the margin of a new position if the free margin covers it. The check and the reservation
+// are made under one lock, so concurrent positions can't reserve more than the free margin
+func (u *User) ReserveMargin(margin money.Amount) bool {
+	u.muPositions.RLock()
+	defer u.muPositions.RUnlock()
+	u.muBalance.Lock()
+	defer u.muBalance.Unlock()
+	if u.balance+u.floating()-u.usedMargin-margin < 0 {
+		return false
+	}
+	u.usedMargin += margin
+	return true
+}
+
+// Withdraw debits the sum from the balance if the free margin covers it. The check and the debit are made under
+// one lock, so the withdrawal can't take the margin of positions which are opening concurrently
+func (u *User) Withdraw(sum money.Amount) bool {
+	u.muPositions.RLock()
+	defer u.muPositions.RUnlock()
+	u.muBalance.Lock()
+	defer u.muBalance.Unlock()
+	if u.balance+u.floating()-u.usedMargin-sum < 0 {
+		return false
+	}
+	u.balance -= sum
+	return true
+}
+
+// ReleaseMargin releases the margin which has been reserved for a position which hasn't opened
+func (u *User) ReleaseMargin(margin money.Amount) {
+	u.muBalance.Lock()
+	u.usedMargin -= margin
+	u.muBalance.Unlock()
+}
+
+// OpenPosition appends position whose margin has been reserved by ReserveMargin
 func (u *User) OpenPosition(position *model.Position) {
+	u.muPositions.Lock()
 	allPositions, ok := u.positions.Load(position.SymbolID)
 	if !ok {
 		m := make(map[int32]*model.Position)
@@ -81,68 +247,431 @@ func (u *User) OpenPosition(position *model.Position) {
 		positions := allPositions.(map[int32]*model.Position)
 		positions[position.ID] = position
 	}
+	u.muPositions.Unlock()
+}
+
+// ClosePosition delete position, books its realized pnl and releases its margin in one step, so the free margin
+// never counts the pnl twice or misses it
+func (u *User) ClosePosition(symbolID, positionID int32, realized money.Amount) {
+	u.muPositions.Lock()
+	p, ok := u.position(symbolID, positionID)
+	if !ok {
+		u.muPositions.Unlock()
+		return
+	}
+	u.deletePosition(p)
+	position := &model.Position{}
+	*position = *p
+	u.muBalance.Lock()
+	u.balance += realized
+	u.usedMargin -= position.Margin
+	u.muBalance.Unlock()
+	u.muPositions.Unlock()
+	u.publish(&model.PositionEvent{
+		PositionID: position.ID,
+		SymbolID:   position.SymbolID,
+		PriceClose: priceClose(position),
+		Pnl:        pnl(position),
+		Closed:     true,
+		Reason:     model.CloseManual,
+	})
+}
+
+// GetPosition returns a copy of open position
+func (u *User) GetPosition(symbolID, positionID int32) (*model.Position, bool) {
+	u.muPositions.RLock()
+	defer u.muPositions.RUnlock()
+	position, ok := u.position(symbolID, positionID)
+	if !ok {
+		return nil, false
+	}
+	p := *position
+	return &p, true
 }
 
-// ClosePosition delete position
-func (u *User) ClosePosition(symbolID, positionID int32) {
+// position returns open position. The caller holds muPositions
+func (u *User) position(symbolID, positionID int32) (*model.Position, bool) {
 	m, ok := u.positions.Load(symbolID)
+	if !ok {
+		return nil, false
+	}
+	positions := m.(map[int32]*model.Position)
+	position, ok := positions[positionID]
+	return position, ok
+}
+
+// deletePosition deletes open position from the map. The caller holds muPositions
+func (u *User) deletePosition(position *model.Position) {
+	m, ok := u.positions.Load(position.SymbolID)
 	if !ok {
 		return
 	}
 	positions := m.(map[int32]*model.Position)
-	delete(positions, positionID)
+	delete(positions, position.ID)
 	if len(positions) == 0 {
-		u.positions.Delete(symbolID)
+		u.positions.Delete(position.SymbolID)
+	}
+}
+
+// GetPositions returns copies of all open positions in order of opening
+func (u *User) GetPositions() []*model.Position {
+	u.muPositions.RLock()
+	defer u.muPositions.RUnlock()
+	var positions []*model.Position
+	u.positions.Range(func(_, m interface{}) bool {
+		for _, position := range m.(map[int32]*model.Position) {
+			p := *position
+			positions = append(positions, &p)
+		}
+		return true
+	})
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].ID < positions[j].ID
+	})
+	return positions
+}
+
+// ModifyPosition changes stop loss and take profit of open position
+func (u *User) ModifyPosition(symbolID, positionID int32, stopLoss, takeProfit money.Amount) {
+	u.muPositions.Lock()
+	defer u.muPositions.Unlock()
+	position, ok := u.position(symbolID, positionID)
+	if !ok {
+		return
+	}
+	position.StopLoss = stopLoss
+	position.TakeProfit = takeProfit
+}
+
+// PlaceOrder appends pending order
+func (u *User) PlaceOrder(order *model.Order) {
+	u.muOrders.Lock()
+	allOrders, ok := u.orders.Load(order.SymbolID)
+	if !ok {
+		m := make(map[int32]*model.Order)
+		m[order.ID] = order
+		u.orders.Store(order.SymbolID, m)
+	} else {
+		orders := allOrders.(map[int32]*model.Order)
+		orders[order.ID] = order
 	}
+	u.muOrders.Unlock()
+	u.subscribe(order.SymbolID)
+}
+
+// Receive merges the price into the prices which wait for the check of orders, so a price which is replaced
+// before the check still triggers them. It doesn't block
+func (u *User) Receive(price *model.Price) {
+	u.muTicks.Lock()
+	if r, ok := u.ticks[price.ID]; ok {
+		r.merge(price)
+	} else {
+		u.ticks[price.ID] = &tickRange{low: *price, high: *price}
+	}
+	u.muTicks.Unlock()
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+}
+
+// checkOrders executes the orders which have been triggered by the prices arrived since the last check
+func (u *User) checkOrders(ctx context.Context) {
+	u.muTicks.Lock()
+	ticks := u.ticks
+	u.ticks = make(map[int32]*tickRange)
+	u.muTicks.Unlock()
+	for symbolID, r := range ticks {
+		u.executeOrders(ctx, symbolID, r)
+	}
+}
+
+// executeOrders turns into positions all orders of the symbol which have been triggered by the range of prices
+func (u *User) executeOrders(ctx context.Context, symbolID int32, r *tickRange) {
+	var orders []*model.Order
+	u.muOrders.Lock()
+	o, ok := u.orders.Load(symbolID)
+	if ok {
+		for _, order := range o.(map[int32]*model.Order) {
+			if triggered(order, &r.low) || triggered(order, &r.high) {
+				orders = append(orders, order)
+			}
+		}
+	}
+	u.muOrders.Unlock()
+	if len(orders) == 0 {
+		return
+	}
+
+	for _, order := range orders {
+		err := u.executor.Execute(ctx, order)
+		switch {
+		case errors.Is(err, request.ErrOrderRejected):
+			log.Warnf("order %d of user %d: %v", order.ID, u.id, err)
+			u.publish(&model.PositionEvent{
+				SymbolID:      order.SymbolID,
+				OrderID:       order.ID,
+				OrderRejected: true,
+				RejectReason:  err.Error(),
+			})
+		case err != nil:
+			log.Error(err)
+			continue
+		}
+		u.muOrders.Lock()
+		if o, ok := u.orders.Load(order.SymbolID); ok {
+			m := o.(map[int32]*model.Order)
+			delete(m, order.ID)
+			if len(m) == 0 {
+				u.orders.Delete(order.SymbolID)
+			}
+		}
+		u.muOrders.Unlock()
+	}
+	u.subscribe(symbolID)
+}
+
+// subscribe subscribes the user to prices of the symbol while the user has pending orders by it and unsubscribes
+// otherwise. Open positions get prices from the risk engine
+func (u *User) subscribe(symbolID int32) {
+	u.muSubscribe.Lock()
+	defer u.muSubscribe.Unlock()
+	_, orders := u.orders.Load(symbolID)
+	if orders {
+		u.registry.Subscribe(symbolID, u)
+	} else {
+		u.registry.Unsubscribe(symbolID, u)
+	}
+}
+
+// ReducePosition decreases count of open position after a part of it has been closed, books the realized pnl
+// of the part and releases its margin in one step
+func (u *User) ReducePosition(symbolID, positionID, count int32, margin, realized money.Amount) {
+	u.muPositions.Lock()
+	defer u.muPositions.Unlock()
+	position, ok := u.position(symbolID, positionID)
+	if !ok {
+		return
+	}
+	position.Count -= count
+	position.Margin -= margin
+	u.muBalance.Lock()
+	u.balance += realized
+	u.usedMargin -= margin
+	u.muBalance.Unlock()
 }
 
 // GetBalance returns balance
-func (u *User) GetBalance() float32 {
+func (u *User) GetBalance() money.Amount {
 	u.muBalance.Lock()
 	defer u.muBalance.Unlock()
 	return u.balance
 }
 
 // ChangeBalance changes balance in user's struct
-func (u *User) ChangeBalance(sum float32) {
+func (u *User) ChangeBalance(sum money.Amount) {
 	u.muBalance.Lock()
 	u.balance += sum
 	u.muBalance.Unlock()
 }
 
-func (u *User) close(ctx context.Context, position *model.Position) error {
-	err := u.closer.Close(ctx, position)
-	if err != nil {
-		return err
+// GetLeverage returns leverage which new positions are opened with
+func (u *User) GetLeverage() int32 {
+	u.muBalance.RLock()
+	defer u.muBalance.RUnlock()
+	return u.leverage
+}
+
+// SetLeverage changes leverage of new positions. Open positions keep their margin
+func (u *User) SetLeverage(leverage int32) {
+	u.muBalance.Lock()
+	u.leverage = leverage
+	u.muBalance.Unlock()
+}
+
+// GetMargin returns equity and margin of the user at the last prices of open positions. Positions which
+// haven't got a price yet are counted without pnl
+func (u *User) GetMargin() *model.Margin {
+	u.muPositions.RLock()
+	u.muBalance.RLock()
+	margin := model.Margin{
+		Leverage:   u.leverage,
+		Balance:    u.balance,
+		Equity:     u.balance + u.floating(),
+		UsedMargin: u.usedMargin,
 	}
-	if position.IsBuy {
-		u.muBalance.Lock()
-		u.balance += position.AskClose * float32(position.Count)
-		u.muBalance.Unlock()
-	} else {
-		u.muBalance.Lock()
-		u.balance -= position.BidClose * float32(position.Count)
-		u.muBalance.Unlock()
+	u.muBalance.RUnlock()
+	u.muPositions.RUnlock()
+	margin.FreeMargin = margin.Equity - margin.UsedMargin
+	if margin.UsedMargin > 0 {
+		margin.MarginLevel = float64(margin.Equity) / float64(margin.UsedMargin) * 100
+	}
+	return &margin
+}
+
+// floating returns pnl of open positions at their last prices. The caller holds muPositions
+func (u *User) floating() money.Amount {
+	var floating money.Amount
+	u.positions.Range(func(_, m interface{}) bool {
+		for _, position := range m.(map[int32]*model.Position) {
+			if quoted(position) {
+				floating += pnl(position)
+			}
+		}
+		return true
+	})
+	return floating
+}
+
+// SubscribePositions returns chan which receives events of user's positions. The chan is closed when ctx is done
+func (u *User) SubscribePositions(ctx context.Context) <-chan *model.PositionEvent {
+	ch := make(chan *model.PositionEvent, eventBuffer)
+	u.muListeners.Lock()
+	u.listeners[ch] = struct{}{}
+	u.muListeners.Unlock()
+	go func() {
+		<-ctx.Done()
+		u.muListeners.Lock()
+		delete(u.listeners, ch)
+		close(ch)
+		u.muListeners.Unlock()
+	}()
+	return ch
+}
+
+// publish sends the event to listeners without blocking. If a listener is slow, its oldest event is dropped
+func (u *User) publish(event *model.PositionEvent) {
+	u.muListeners.RLock()
+	defer u.muListeners.RUnlock()
+	for ch := range u.listeners {
+		feed.Send(ch, event)
 	}
+}
 
-	p, ok := u.positions.Load(position.SymbolID)
+// close closes the position at its last price
+func (u *User) close(ctx context.Context, open *model.Position, reason model.CloseReason) error {
+	u.muPositions.RLock()
+	p, ok := u.position(open.SymbolID, open.ID)
 	if !ok {
-		log.Errorf("position %d successful close but didn't delete from map", position.ID)
+		u.muPositions.RUnlock()
+		return fmt.Errorf("position %d is already closed", open.ID)
 	}
-	positions := p.(map[int32]*model.Position)
-	delete(positions, position.ID)
-	if len(positions) == 0 {
-		u.positions.Delete(position.SymbolID)
+	position := &model.Position{}
+	*position = *p
+	u.muPositions.RUnlock()
+
+	err := u.closer.Close(ctx, position, reason)
+	if err != nil {
+		return err
 	}
+	u.muPositions.Lock()
+	u.deletePosition(position)
+	u.muBalance.Lock()
+	u.balance += pnl(position)
+	u.usedMargin -= position.Margin
+	u.muBalance.Unlock()
+	u.muPositions.Unlock()
+	u.publish(&model.PositionEvent{
+		PositionID: position.ID,
+		SymbolID:   position.SymbolID,
+		PriceClose: priceClose(position),
+		Pnl:        pnl(position),
+		Closed:     true,
+		Reason:     reason,
+	})
 	return nil
 }
 
+// priceClose returns the price at which the position is closed now
+func priceClose(position *model.Position) money.Amount {
+	if position.IsBuy {
+		return position.AskClose
+	}
+	return position.BidClose
+}
+
+// quoted returns true if a price of the position's symbol has arrived since the position was loaded
+func quoted(position *model.Position) bool {
+	return position.BidClose != 0 || position.AskClose != 0
+}
+
 // pnl is Profit and loss. Shows how much you earned or lost
-func pnl(position *model.Position) float32 {
+func pnl(position *model.Position) money.Amount {
 	if position.IsBuy {
-		return position.AskClose * float32(position.Count) - position.PriceOpen * float32(position.Count)
+		return (position.AskClose - position.PriceOpen).Mul(position.Count)
+	}
+	return (position.PriceOpen - position.BidClose).Mul(position.Count)
+}
+
+// tickRange keeps the least and the largest bid and ask of a symbol since its orders were checked
+type tickRange struct {
+	low  model.Price
+	high model.Price
+}
+
+// merge widens the range by the price
+func (r *tickRange) merge(price *model.Price) {
+	if price.Bid < r.low.Bid {
+		r.low.Bid = price.Bid
+	}
+	if price.Ask < r.low.Ask {
+		r.low.Ask = price.Ask
+	}
+	if price.Bid > r.high.Bid {
+		r.high.Bid = price.Bid
+	}
+	if price.Ask > r.high.Ask {
+		r.high.Ask = price.Ask
+	}
+}
+
+// triggered returns true if the order must be turned into a position at this price
+func triggered(order *model.Order, price *model.Price) bool {
+	switch order.Type {
+	case model.OrderLimit, model.OrderMarketIfTouched:
+		return limitReached(order, price)
+	case model.OrderStop:
+		return stopReached(order, price)
+	default:
+		return false
 	}
-	return position.PriceOpen * float32(position.Count) - position.BidClose * float32(position.Count)
+}
+
+// limitReached returns true if the order can be filled at its price or better
+func limitReached(order *model.Order, price *model.Price) bool {
+	if order.IsBuy {
+		return price.Bid <= order.Price
+	}
+	return price.Ask >= order.Price
+}
+
+// stopReached returns true if the price has moved through the order's price
+func stopReached(order *model.Order, price *model.Price) bool {
+	if order.IsBuy {
+		return price.Bid >= order.Price
+	}
+	return price.Ask <= order.Price
+}
+
+// trailStopLoss moves stop loss behind the best price. Returns true if stop loss has moved
+func trailStopLoss(position *model.Position) bool {
+	if position.TrailingStop <= 0 {
+		return false
+	}
+	if position.IsBuy {
+		level := position.AskClose - position.TrailingStop
+		if level > position.StopLoss {
+			position.StopLoss = level
+			return true
+		}
+		return false
+	}
+	level := position.BidClose + position.TrailingStop
+	if position.StopLoss == 0 || level < position.StopLoss {
+		position.StopLoss = level
+		return true
+	}
+	return false
 }
 
 func stopLoss(position *model.Position) bool {
@@ -159,32 +688,7 @@ func takeProfit(position *model.Position) bool {
 	return position.BidClose <= position.TakeProfit
 }
 
-func (u *User) marginCall(position *model.Position) bool {
-	u.muBalance.RLock()
-	bln := u.balance
-	u.muBalance.RUnlock()
-
-	u.positions.Range(func(key, value interface{}) bool {
-		positions := value.(map[int32]*model.Position)
-		for _, pos := range positions {
-			p := pnl(pos)
-			if position.IsBuy {
-				bln = bln + pos.PriceOpen * float32(position.Count) + p
-			} else {
-				bln = bln - pos.PriceOpen * float32(position.Count) + p
-			}
-		}
-		return true
-	})
-	return bln < 0
-}
-
 // GetID returns id
 func (u *User) GetID() int32 {
 	return u.id
 }
-
-// GetChanPrice returns chan of price
-func (u *User) GetChanPrice() chan *model.Price {
-	return u.chPrice
-}
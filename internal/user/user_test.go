@@ -2,8 +2,13 @@ package user
 
 import (
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/money"
+	"github.com/chucky-1/broker/internal/request"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"context"
+	"sync"
 	"testing"
 )
 
@@ -110,3 +115,353 @@ func TestUser_takeProfit(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_limitReached(t *testing.T) {
+	testTable := []struct {
+		name   string
+		order  *model.Order
+		price  *model.Price
+		expect bool
+	}{
+		{
+			name:   "OK if isBuy is true",
+			order:  &model.Order{Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 990, Ask: 995},
+			expect: true,
+		},
+		{
+			name:   "OK if isBuy is false",
+			order:  &model.Order{Price: 1000, IsBuy: false},
+			price:  &model.Price{Bid: 1005, Ask: 1010},
+			expect: true,
+		},
+		{
+			name:   "Failed if isBuy is true",
+			order:  &model.Order{Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 1010, Ask: 1015},
+			expect: false,
+		},
+		{
+			name:   "Failed if isBuy is false",
+			order:  &model.Order{Price: 1000, IsBuy: false},
+			price:  &model.Price{Bid: 985, Ask: 990},
+			expect: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := limitReached(testCase.order, testCase.price)
+			assert.Equal(t, testCase.expect, b)
+		})
+	}
+}
+
+func TestUser_triggered(t *testing.T) {
+	testTable := []struct {
+		name   string
+		order  *model.Order
+		price  *model.Price
+		expect bool
+	}{
+		{
+			name:   "Limit order is triggered below the price if isBuy is true",
+			order:  &model.Order{Type: model.OrderLimit, Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 990, Ask: 995},
+			expect: true,
+		},
+		{
+			name:   "Stop order is triggered above the price if isBuy is true",
+			order:  &model.Order{Type: model.OrderStop, Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 1010, Ask: 1015},
+			expect: true,
+		},
+		{
+			name:   "Stop order isn't triggered below the price if isBuy is true",
+			order:  &model.Order{Type: model.OrderStop, Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 990, Ask: 995},
+			expect: false,
+		},
+		{
+			name:   "Stop order is triggered below the price if isBuy is false",
+			order:  &model.Order{Type: model.OrderStop, Price: 1000, IsBuy: false},
+			price:  &model.Price{Bid: 985, Ask: 990},
+			expect: true,
+		},
+		{
+			name:   "Stop order isn't triggered above the price if isBuy is false",
+			order:  &model.Order{Type: model.OrderStop, Price: 1000, IsBuy: false},
+			price:  &model.Price{Bid: 1005, Ask: 1010},
+			expect: false,
+		},
+		{
+			name:   "Market-if-touched order is triggered above the price if isBuy is false",
+			order:  &model.Order{Type: model.OrderMarketIfTouched, Price: 1000, IsBuy: false},
+			price:  &model.Price{Bid: 1005, Ask: 1010},
+			expect: true,
+		},
+		{
+			name:   "Unknown order type is never triggered",
+			order:  &model.Order{Type: 10, Price: 1000, IsBuy: true},
+			price:  &model.Price{Bid: 990, Ask: 995},
+			expect: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := triggered(testCase.order, testCase.price)
+			assert.Equal(t, testCase.expect, b)
+		})
+	}
+}
+
+func TestUser_checkOrders(t *testing.T) {
+	testTable := []struct {
+		name           string
+		bids           []int64 // ask is bid + 1, all prices arrive before the check
+		expectExecuted []int32
+	}{
+		{
+			name:           "Limit order is filled by a price which has been replaced before the check",
+			bids:           []int64{6, 5, 6},
+			expectExecuted: []int32{1},
+		},
+		{
+			name:           "Stop order is triggered by a price which has been replaced before the check",
+			bids:           []int64{6, 9, 6},
+			expectExecuted: []int32{2},
+		},
+		{
+			name: "Nothing if no price has reached the orders",
+			bids: []int64{6, 7, 6},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			orders := new(sync.Map)
+			orders.Store(int32(1), map[int32]*model.Order{
+				1: {ID: 1, SymbolID: 1, Type: model.OrderLimit, Count: 1, Price: money.New(5), IsBuy: true},
+				2: {ID: 2, SymbolID: 1, Type: model.OrderStop, Count: 1, Price: money.New(8), IsBuy: true},
+			})
+			broker := &testBroker{subscribed: map[int32]bool{1: true}}
+			u := &User{
+				orders:    orders,
+				executor:  broker,
+				registry:  broker,
+				ticks:     make(map[int32]*tickRange),
+				wake:      make(chan struct{}, 1),
+				listeners: make(map[chan *model.PositionEvent]struct{}),
+			}
+
+			for _, bid := range testCase.bids {
+				u.Receive(&model.Price{ID: 1, Bid: money.New(bid), Ask: money.New(bid + 1)})
+			}
+			assert.Len(t, u.wake, 1, "the prices wake the user once")
+			u.checkOrders(context.Background())
+			assert.Equal(t, testCase.expectExecuted, broker.executed)
+			assert.Empty(t, u.ticks, "the checked prices are forgotten")
+			assert.True(t, broker.subscribed[1], "the pending order waits for prices")
+		})
+	}
+}
+
+func TestUser_trailStopLoss(t *testing.T) {
+	testTable := []struct {
+		name     string
+		position *model.Position
+		expect   bool
+		stopLoss money.Amount
+	}{
+		{
+			name: "Moves up if isBuy is true",
+			position: &model.Position{
+				StopLoss:     900,
+				TrailingStop: 50,
+				AskClose:     1000,
+				IsBuy:        true,
+			},
+			expect:   true,
+			stopLoss: 950,
+		},
+		{
+			name: "Doesn't move down if isBuy is true",
+			position: &model.Position{
+				StopLoss:     950,
+				TrailingStop: 50,
+				AskClose:     980,
+				IsBuy:        true,
+			},
+			expect:   false,
+			stopLoss: 950,
+		},
+		{
+			name: "Moves down if isBuy is false",
+			position: &model.Position{
+				StopLoss:     1100,
+				TrailingStop: 50,
+				BidClose:     1000,
+				IsBuy:        false,
+			},
+			expect:   true,
+			stopLoss: 1050,
+		},
+		{
+			name: "Doesn't move up if isBuy is false",
+			position: &model.Position{
+				StopLoss:     1050,
+				TrailingStop: 50,
+				BidClose:     1020,
+				IsBuy:        false,
+			},
+			expect:   false,
+			stopLoss: 1050,
+		},
+		{
+			name: "Sets stop loss if it is empty and isBuy is false",
+			position: &model.Position{
+				TrailingStop: 50,
+				BidClose:     1000,
+				IsBuy:        false,
+			},
+			expect:   true,
+			stopLoss: 1050,
+		},
+		{
+			name: "Doesn't move without trailing stop",
+			position: &model.Position{
+				StopLoss: 900,
+				AskClose: 1000,
+				IsBuy:    true,
+			},
+			expect:   false,
+			stopLoss: 900,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := trailStopLoss(testCase.position)
+			assert.Equal(t, testCase.expect, b)
+			assert.Equal(t, testCase.stopLoss, testCase.position.StopLoss)
+		})
+	}
+}
+
+func TestUser_publish(t *testing.T) {
+	u := &User{listeners: make(map[chan *model.PositionEvent]struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := u.SubscribePositions(ctx)
+
+	for i := 0; i <= eventBuffer; i++ {
+		u.publish(&model.PositionEvent{PositionID: int32(i)})
+	}
+	assert.Len(t, events, eventBuffer)
+	first := <-events
+	assert.Equal(t, int32(1), first.PositionID, "the oldest event must be dropped")
+
+	cancel()
+	for range events {
+	}
+	_, ok := <-events
+	assert.False(t, ok, "chan must be closed when ctx is done")
+}
+
+// testBroker closes positions, stores margin events, executes orders and keeps subscriptions to prices without
+// a database
+type testBroker struct {
+	closed     []int32
+	events     []*model.MarginEvent
+	subscribed map[int32]bool
+	executed   []int32
+}
+
+func (b *testBroker) Close(ctx context.Context, position *model.Position, reason model.CloseReason) error {
+	b.closed = append(b.closed, position.ID)
+	return nil
+}
+
+func (b *testBroker) AuditMargin(ctx context.Context, event *model.MarginEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *testBroker) Subscribe(symbolID int32, subscriber request.PriceSubscriber) {
+	b.subscribed[symbolID] = true
+}
+
+func (b *testBroker) Unsubscribe(symbolID int32, subscriber request.PriceSubscriber) {
+	b.subscribed[symbolID] = false
+}
+
+func (b *testBroker) Execute(ctx context.Context, order *model.Order) error {
+	b.executed = append(b.executed, order.ID)
+	return nil
+}
+
+func TestUser_controlMargin(t *testing.T) {
+	testTable := []struct {
+		name         string
+		askFirst     money.Amount
+		askSecond    money.Amount
+		expectClosed []int32
+		expectEvents []model.MarginEventType
+	}{
+		{
+			name:         "Nothing if margin level is above margin call",
+			askFirst:     money.New(10),
+			askSecond:    money.New(10),
+			expectEvents: []model.MarginEventType{},
+		},
+		{
+			name:         "Warns if margin level is below margin call",
+			askFirst:     money.New(8),
+			askSecond:    money.New(10),
+			expectEvents: []model.MarginEventType{model.MarginCallWarning},
+		},
+		{
+			name:         "Closes the worst loss until margin level is above stop out",
+			askFirst:     money.New(4),
+			askSecond:    money.New(10),
+			expectClosed: []int32{1},
+			expectEvents: []model.MarginEventType{model.MarginCallWarning, model.MarginStopOut},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			positions := new(sync.Map)
+			positions.Store(int32(1), map[int32]*model.Position{
+				1: {ID: 1, SymbolID: 1, Count: 10, PriceOpen: money.New(10), AskClose: testCase.askFirst,
+					BidClose: testCase.askFirst, IsBuy: true, Margin: money.New(50)},
+			})
+			positions.Store(int32(2), map[int32]*model.Position{
+				2: {ID: 2, SymbolID: 2, Count: 10, PriceOpen: money.New(10), AskClose: testCase.askSecond,
+					BidClose: testCase.askSecond, IsBuy: true, Margin: money.New(50)},
+			})
+			orders := new(sync.Map)
+			orders.Store(int32(2), map[int32]*model.Order{
+				1: {ID: 1, SymbolID: 2, Type: model.OrderLimit, Count: 1, Price: money.New(1), IsBuy: true},
+			})
+			broker := &testBroker{subscribed: make(map[int32]bool)}
+			u, err := NewUser(ctx, 1, money.New(100), 1, model.MarginLevels{MarginCall: 100, StopOut: 50},
+				positions, orders, broker, nil, nil, broker, broker)
+			require.NoError(t, err)
+
+			closed := u.ControlMargin(ctx)
+			closed = append(closed, u.ControlMargin(ctx)...)
+			assert.Equal(t, testCase.expectClosed, broker.closed)
+			assert.Len(t, closed, len(testCase.expectClosed))
+			types := make([]model.MarginEventType, 0, len(broker.events))
+			for _, event := range broker.events {
+				types = append(types, event.Type)
+			}
+			assert.Equal(t, testCase.expectEvents, types, "the warning is sent once")
+			assert.False(t, broker.subscribed[1], "open positions get prices from the risk engine")
+			assert.True(t, broker.subscribed[2], "the pending order waits for prices")
+		})
+	}
+}
@@ -2,14 +2,19 @@ package main
 
 import (
 	"github.com/caarlos0/env/v6"
+	"github.com/chucky-1/broker/internal/auth"
 	"github.com/chucky-1/broker/internal/config"
+	"github.com/chucky-1/broker/internal/grpc/interceptor"
 	"github.com/chucky-1/broker/internal/grpc/server"
 	"github.com/chucky-1/broker/internal/model"
+	"github.com/chucky-1/broker/internal/pricer"
 	"github.com/chucky-1/broker/internal/repository"
+	"github.com/chucky-1/broker/internal/request"
+	"github.com/chucky-1/broker/internal/risk"
 	"github.com/chucky-1/broker/internal/service"
 	"github.com/chucky-1/broker/protocol"
-	pricer "github.com/chucky-1/pricer/protocol"
-	"github.com/jackc/pgx/v4"
+	prices "github.com/chucky-1/pricer/protocol"
+	"github.com/jackc/pgx/v4/pgxpool"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
@@ -19,6 +24,7 @@ import (
 	"strconv"
 )
 
+// countOfSymbols is a count of symbols which are added when the broker runs without a database
 const countOfSymbols = 5
 
 func main() {
@@ -28,36 +34,55 @@ func main() {
 		log.Fatalf("%v", err)
 	}
 
-	// Postgres
-	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
-		cfg.UsernamePostgres, cfg.PasswordPostgres, cfg.HostPostgres, cfg.PortPostgres, cfg.DBNamePostgres)
-	conn, err := pgx.Connect(context.Background(), url)
-	if err != nil {
-		log.Fatalf("Unable to connect to database: %v", err)
+	// Storage
+	var rep repository.Repository
+	if cfg.Storage == "memory" {
+		log.Warn("positions and balances are kept in memory and are lost on exit")
+		rep = repository.NewMemory()
+	} else {
+		pool := connectPostgres(cfg)
+		defer pool.Close()
+		rep = repository.NewPostgres(pool)
 	}
-	defer func(conn *pgx.Conn, ctx context.Context) {
-		err = conn.Close(ctx)
-		if err != nil {
-			log.Error(err)
-		}
-	}(conn, context.Background())
 
 	// Initial dependencies
-	symbols := map[int32]*model.Symbol{}
-	symbolID := make([]int32, 0, countOfSymbols)
-	for i := 0; i < countOfSymbols; i++ {
-		title := fmt.Sprint("Symbol ", strconv.Itoa(i+1))
-		symbols[int32(i+1)] = &model.Symbol{
-			ID:    int32(i+1),
-			Title: title,
-		}
-		symbolID = append(symbolID, int32(i+1))
-	}
-	ch := make(chan *model.Price) // this chan is listened in main.go
 	chSrv := make(chan *model.Price) // this chan is listened in service.go
 	ctx := context.Background()
-	rep := repository.NewRepository(conn)
-	srv, err := service.NewService(ctx, rep, chSrv, symbols)
+	if cfg.Storage == "memory" {
+		addDefaultSymbols(ctx, rep)
+	}
+	if cfg.RebuildBalances {
+		count, err := rep.RebuildBalances(ctx)
+		if err != nil {
+			log.Fatalf("Unable to rebuild balances: %v", err)
+		}
+		log.Infof("balances of %d users have been rebuilt from the ledger", count)
+	}
+	engine, err := risk.NewEngine(ctx, cfg.RiskWorkers, cfg.RiskQueueSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv, err := service.NewService(ctx, rep, chSrv, cfg.Leverage, model.MarginLevels{
+		MarginCall: cfg.MarginCallLevel,
+		StopOut:    cfg.StopOutLevel,
+	}, engine, cfg.QuoteTTL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Grpc Prices
+	clientConn, err := grpc.Dial(fmt.Sprint(cfg.HostGrpcClient, ":", cfg.PortGrpcClient), grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("fail to dial: %v", err)
+	}
+	defer func(conn *grpc.ClientConn) {
+		err = conn.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}(clientConn)
+	pricesClient, err := pricer.NewClient(prices.NewPricesClient(clientConn), srv, chSrv,
+		cfg.PricerMinBackoff, cfg.PricerMaxBackoff, cfg.PricerStaleAfter)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -69,64 +94,63 @@ func main() {
 		if err != nil {
 			log.Fatalf("failed to listen: %v", err)
 		}
-		s := grpc.NewServer()
-		protocol.RegisterBrokerServer(s, server.NewServer(srv))
+		tokens := auth.NewManager(cfg.TokenSecret, cfg.TokenTTL)
+		authInterceptor := interceptor.NewAuth(tokens,
+			fmt.Sprintf("/%s/SignUp", protocol.Broker_ServiceDesc.ServiceName),
+			fmt.Sprintf("/%s/SignIn", protocol.Broker_ServiceDesc.ServiceName)).
+			WithAdmin(cfg.AdminKey,
+				fmt.Sprintf("/%s/AddSymbol", protocol.Admin_ServiceDesc.ServiceName),
+				fmt.Sprintf("/%s/DisableSymbol", protocol.Admin_ServiceDesc.ServiceName),
+				fmt.Sprintf("/%s/SetLeverage", protocol.Admin_ServiceDesc.ServiceName),
+				fmt.Sprintf("/%s/GetEngineStats", protocol.Admin_ServiceDesc.ServiceName),
+				fmt.Sprintf("/%s/GetPricerStatus", protocol.Admin_ServiceDesc.ServiceName))
+		s := grpc.NewServer(
+			grpc.UnaryInterceptor(authInterceptor.Unary()),
+			grpc.StreamInterceptor(authInterceptor.Stream()),
+		)
+		protocol.RegisterBrokerServer(s, server.NewServer(srv, tokens))
+		protocol.RegisterAdminServer(s, server.NewAdmin(srv, pricesClient))
 		log.Infof("server listening at %v", lis.Addr())
 		if err = s.Serve(lis); err != nil {
 			log.Fatalf("failed to serve: %v", err)
 		}
 	}()
 
-	// Grpc Prices
-	go func() {
-		hostAndPort := fmt.Sprint(cfg.HostGrpcClient, ":", cfg.PortGrpcClient)
-		clientConn, err := grpc.Dial(hostAndPort, grpc.WithInsecure())
-		if err != nil {
-			log.Fatalf("fail to dial: %v", err)
-		}
-		defer func(conn *grpc.ClientConn) {
-			err = conn.Close()
-			if err != nil {
-				log.Fatal(err)
-			}
-		}(clientConn)
-		client := pricer.NewPricesClient(clientConn)
-		stream, err := client.Subscribe(context.Background())
-		if err != nil {
-			return
-		}
-		err = stream.Send(&pricer.SubscribeRequest{
-			Action:   0,
-			PriceId: symbolID,
+	// the pricer client reconnects until the broker stops
+	pricesClient.Run(ctx)
+}
+
+// addDefaultSymbols adds symbols with ids of prices which the pricer sends
+func addDefaultSymbols(ctx context.Context, rep repository.Repository) {
+	for i := 1; i <= countOfSymbols; i++ {
+		_, err := rep.AddSymbol(ctx, &request.AddSymbol{
+			Ticker:   fmt.Sprint("SYM", i),
+			Title:    fmt.Sprint("Symbol ", strconv.Itoa(i)),
+			LotSize:  1,
+			TickSize: 1, // the smallest amount
+			Currency: "USD",
 		})
 		if err != nil {
-			return
+			log.Fatal(err)
 		}
+	}
+}
 
-		for {
-			select {
-			case <-stream.Context().Done():
-				return
-			default:
-				price, err := stream.Recv()
-				if err != nil {
-					log.Error(err)
-					continue
-				}
-				s := &model.Price{
-					ID:   price.PriceId,
-					Bid:  price.Bid,
-					Ask:  price.Ask,
-					Time: price.Update.Seconds,
-				}
-				// ch <- s
-				chSrv <- s
-			}
-		}
-	}()
-
-	for {
-		symbol := <-ch
-		log.Info(symbol)
+// connectPostgres creates the pool of connections to postgres
+func connectPostgres(cfg *config.Config) *pgxpool.Pool {
+	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+		cfg.UsernamePostgres, cfg.PasswordPostgres, cfg.HostPostgres, cfg.PortPostgres, cfg.DBNamePostgres)
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		log.Fatalf("Unable to parse database config: %v", err)
+	}
+	poolConfig.MaxConns = cfg.MaxConnsPostgres
+	poolConfig.MinConns = cfg.MinConnsPostgres
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriodPostgres
+	poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeoutPostgres.Milliseconds(), 10)
+	pool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
+	if err != nil {
+		log.Fatalf("Unable to connect to database: %v", err)
 	}
+	return pool
 }
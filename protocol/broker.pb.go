@@ -20,18 +20,236 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OrderType int32
+
+const (
+	OrderType_LIMIT             OrderType = 0
+	OrderType_STOP              OrderType = 1
+	OrderType_MARKET_IF_TOUCHED OrderType = 2
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "LIMIT",
+		1: "STOP",
+		2: "MARKET_IF_TOUCHED",
+	}
+	OrderType_value = map[string]int32{
+		"LIMIT":             0,
+		"STOP":              1,
+		"MARKET_IF_TOUCHED": 2,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_broker_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_protocol_broker_proto_enumTypes[0]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{0}
+}
+
+type CloseReason int32
+
+const (
+	CloseReason_MANUAL      CloseReason = 0
+	CloseReason_STOP_LOSS   CloseReason = 1
+	CloseReason_TAKE_PROFIT CloseReason = 2
+	CloseReason_MARGIN_CALL CloseReason = 3
+	CloseReason_ADMIN       CloseReason = 4
+)
+
+// Enum value maps for CloseReason.
+var (
+	CloseReason_name = map[int32]string{
+		0: "MANUAL",
+		1: "STOP_LOSS",
+		2: "TAKE_PROFIT",
+		3: "MARGIN_CALL",
+		4: "ADMIN",
+	}
+	CloseReason_value = map[string]int32{
+		"MANUAL":      0,
+		"STOP_LOSS":   1,
+		"TAKE_PROFIT": 2,
+		"MARGIN_CALL": 3,
+		"ADMIN":       4,
+	}
+)
+
+func (x CloseReason) Enum() *CloseReason {
+	p := new(CloseReason)
+	*p = x
+	return p
+}
+
+func (x CloseReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CloseReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_broker_proto_enumTypes[1].Descriptor()
+}
+
+func (CloseReason) Type() protoreflect.EnumType {
+	return &file_protocol_broker_proto_enumTypes[1]
+}
+
+func (x CloseReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CloseReason.Descriptor instead.
+func (CloseReason) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{1}
+}
+
+type EntryType int32
+
+const (
+	EntryType_DEPOSIT        EntryType = 0
+	EntryType_WITHDRAWAL     EntryType = 1
+	EntryType_POSITION_OPEN  EntryType = 2
+	EntryType_POSITION_CLOSE EntryType = 3
+	EntryType_ADJUSTMENT     EntryType = 5
+)
+
+// Enum value maps for EntryType.
+var (
+	EntryType_name = map[int32]string{
+		0: "DEPOSIT",
+		1: "WITHDRAWAL",
+		2: "POSITION_OPEN",
+		3: "POSITION_CLOSE",
+		5: "ADJUSTMENT",
+	}
+	EntryType_value = map[string]int32{
+		"DEPOSIT":        0,
+		"WITHDRAWAL":     1,
+		"POSITION_OPEN":  2,
+		"POSITION_CLOSE": 3,
+		"ADJUSTMENT":     5,
+	}
+)
+
+func (x EntryType) Enum() *EntryType {
+	p := new(EntryType)
+	*p = x
+	return p
+}
+
+func (x EntryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_broker_proto_enumTypes[2].Descriptor()
+}
+
+func (EntryType) Type() protoreflect.EnumType {
+	return &file_protocol_broker_proto_enumTypes[2]
+}
+
+func (x EntryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryType.Descriptor instead.
+func (EntryType) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{2}
+}
+
+// Money is an exact decimal amount: units + nanos / 10^9. Both parts have the same sign.
+// The broker keeps 6 decimal places and rounds nanos half away from zero
+type Money struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Units int64 `protobuf:"varint,1,opt,name=units,proto3" json:"units,omitempty"`
+	Nanos int32 `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Money) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
 type SignUpRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Deposit float32 `protobuf:"fixed32,1,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	Login    string `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Deposit  *Money `protobuf:"bytes,4,opt,name=deposit,proto3" json:"deposit,omitempty"`
 }
 
 func (x *SignUpRequest) Reset() {
 	*x = SignUpRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[0]
+		mi := &file_protocol_broker_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -44,7 +262,7 @@ func (x *SignUpRequest) String() string {
 func (*SignUpRequest) ProtoMessage() {}
 
 func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[0]
+	mi := &file_protocol_broker_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,14 +275,28 @@ func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignUpRequest.ProtoReflect.Descriptor instead.
 func (*SignUpRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{0}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignUpRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
 }
 
-func (x *SignUpRequest) GetDeposit() float32 {
+func (x *SignUpRequest) GetDeposit() *Money {
 	if x != nil {
 		return x.Deposit
 	}
-	return 0
+	return nil
 }
 
 type SignUpResponse struct {
@@ -78,7 +310,7 @@ type SignUpResponse struct {
 func (x *SignUpResponse) Reset() {
 	*x = SignUpResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[1]
+		mi := &file_protocol_broker_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -91,7 +323,7 @@ func (x *SignUpResponse) String() string {
 func (*SignUpResponse) ProtoMessage() {}
 
 func (x *SignUpResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[1]
+	mi := &file_protocol_broker_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -104,7 +336,7 @@ func (x *SignUpResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignUpResponse.ProtoReflect.Descriptor instead.
 func (*SignUpResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{1}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SignUpResponse) GetUserId() int32 {
@@ -119,13 +351,14 @@ type SignInRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId int32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Login    string `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 }
 
 func (x *SignInRequest) Reset() {
 	*x = SignInRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[2]
+		mi := &file_protocol_broker_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -138,7 +371,7 @@ func (x *SignInRequest) String() string {
 func (*SignInRequest) ProtoMessage() {}
 
 func (x *SignInRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[2]
+	mi := &file_protocol_broker_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,26 +384,36 @@ func (x *SignInRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignInRequest.ProtoReflect.Descriptor instead.
 func (*SignInRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{2}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SignInRequest) GetUserId() int32 {
+func (x *SignInRequest) GetLogin() string {
 	if x != nil {
-		return x.UserId
+		return x.Login
 	}
-	return 0
+	return ""
+}
+
+func (x *SignInRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
 }
 
 type SignInResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId int32  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
 func (x *SignInResponse) Reset() {
 	*x = SignInResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[3]
+		mi := &file_protocol_broker_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -183,7 +426,7 @@ func (x *SignInResponse) String() string {
 func (*SignInResponse) ProtoMessage() {}
 
 func (x *SignInResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[3]
+	mi := &file_protocol_broker_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +439,21 @@ func (x *SignInResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
 func (*SignInResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{3}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignInResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
 type OpenPositionRequest struct {
@@ -204,19 +461,19 @@ type OpenPositionRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId     int32   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	SymbolId   int32   `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
-	Price      float32 `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
-	Count      int32   `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	StopLoss   float32 `protobuf:"fixed32,5,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
-	TakeProfit float32 `protobuf:"fixed32,6,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
-	IsBuy      bool    `protobuf:"varint,7,opt,name=is_buy,json=isBuy,proto3" json:"is_buy,omitempty"`
+	SymbolId     int32  `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	Count        int32  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	IsBuy        bool   `protobuf:"varint,7,opt,name=is_buy,json=isBuy,proto3" json:"is_buy,omitempty"`
+	TrailingStop *Money `protobuf:"bytes,8,opt,name=trailing_stop,json=trailingStop,proto3" json:"trailing_stop,omitempty"`
+	Price        *Money `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`
+	StopLoss     *Money `protobuf:"bytes,10,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit   *Money `protobuf:"bytes,11,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
 }
 
 func (x *OpenPositionRequest) Reset() {
 	*x = OpenPositionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[4]
+		mi := &file_protocol_broker_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -229,7 +486,7 @@ func (x *OpenPositionRequest) String() string {
 func (*OpenPositionRequest) ProtoMessage() {}
 
 func (x *OpenPositionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[4]
+	mi := &file_protocol_broker_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,56 +499,56 @@ func (x *OpenPositionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenPositionRequest.ProtoReflect.Descriptor instead.
 func (*OpenPositionRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{4}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *OpenPositionRequest) GetUserId() int32 {
+func (x *OpenPositionRequest) GetSymbolId() int32 {
 	if x != nil {
-		return x.UserId
+		return x.SymbolId
 	}
 	return 0
 }
 
-func (x *OpenPositionRequest) GetSymbolId() int32 {
+func (x *OpenPositionRequest) GetCount() int32 {
 	if x != nil {
-		return x.SymbolId
+		return x.Count
 	}
 	return 0
 }
 
-func (x *OpenPositionRequest) GetPrice() float32 {
+func (x *OpenPositionRequest) GetIsBuy() bool {
 	if x != nil {
-		return x.Price
+		return x.IsBuy
 	}
-	return 0
+	return false
 }
 
-func (x *OpenPositionRequest) GetCount() int32 {
+func (x *OpenPositionRequest) GetTrailingStop() *Money {
 	if x != nil {
-		return x.Count
+		return x.TrailingStop
 	}
-	return 0
+	return nil
 }
 
-func (x *OpenPositionRequest) GetStopLoss() float32 {
+func (x *OpenPositionRequest) GetPrice() *Money {
 	if x != nil {
-		return x.StopLoss
+		return x.Price
 	}
-	return 0
+	return nil
 }
 
-func (x *OpenPositionRequest) GetTakeProfit() float32 {
+func (x *OpenPositionRequest) GetStopLoss() *Money {
 	if x != nil {
-		return x.TakeProfit
+		return x.StopLoss
 	}
-	return 0
+	return nil
 }
 
-func (x *OpenPositionRequest) GetIsBuy() bool {
+func (x *OpenPositionRequest) GetTakeProfit() *Money {
 	if x != nil {
-		return x.IsBuy
+		return x.TakeProfit
 	}
-	return false
+	return nil
 }
 
 type OpenPositionResponse struct {
@@ -305,7 +562,7 @@ type OpenPositionResponse struct {
 func (x *OpenPositionResponse) Reset() {
 	*x = OpenPositionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[5]
+		mi := &file_protocol_broker_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -318,7 +575,7 @@ func (x *OpenPositionResponse) String() string {
 func (*OpenPositionResponse) ProtoMessage() {}
 
 func (x *OpenPositionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[5]
+	mi := &file_protocol_broker_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,7 +588,7 @@ func (x *OpenPositionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenPositionResponse.ProtoReflect.Descriptor instead.
 func (*OpenPositionResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{5}
+	return file_protocol_broker_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OpenPositionResponse) GetPositionId() int32 {
@@ -341,31 +598,33 @@ func (x *OpenPositionResponse) GetPositionId() int32 {
 	return 0
 }
 
-type ClosePositionRequest struct {
+type ModifyPositionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PositionId int32 `protobuf:"varint,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	PositionId int32  `protobuf:"varint,2,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	StopLoss   *Money `protobuf:"bytes,3,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit *Money `protobuf:"bytes,4,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
 }
 
-func (x *ClosePositionRequest) Reset() {
-	*x = ClosePositionRequest{}
+func (x *ModifyPositionRequest) Reset() {
+	*x = ModifyPositionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[6]
+		mi := &file_protocol_broker_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClosePositionRequest) String() string {
+func (x *ModifyPositionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClosePositionRequest) ProtoMessage() {}
+func (*ModifyPositionRequest) ProtoMessage() {}
 
-func (x *ClosePositionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[6]
+func (x *ModifyPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -376,41 +635,55 @@ func (x *ClosePositionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClosePositionRequest.ProtoReflect.Descriptor instead.
-func (*ClosePositionRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ModifyPositionRequest.ProtoReflect.Descriptor instead.
+func (*ModifyPositionRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ClosePositionRequest) GetPositionId() int32 {
+func (x *ModifyPositionRequest) GetPositionId() int32 {
 	if x != nil {
 		return x.PositionId
 	}
 	return 0
 }
 
-type ClosePositionResponse struct {
+func (x *ModifyPositionRequest) GetStopLoss() *Money {
+	if x != nil {
+		return x.StopLoss
+	}
+	return nil
+}
+
+func (x *ModifyPositionRequest) GetTakeProfit() *Money {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return nil
+}
+
+type ModifyPositionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *ClosePositionResponse) Reset() {
-	*x = ClosePositionResponse{}
+func (x *ModifyPositionResponse) Reset() {
+	*x = ModifyPositionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[7]
+		mi := &file_protocol_broker_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClosePositionResponse) String() string {
+func (x *ModifyPositionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClosePositionResponse) ProtoMessage() {}
+func (*ModifyPositionResponse) ProtoMessage() {}
 
-func (x *ClosePositionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[7]
+func (x *ModifyPositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,37 +694,37 @@ func (x *ClosePositionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClosePositionResponse.ProtoReflect.Descriptor instead.
-func (*ClosePositionResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ModifyPositionResponse.ProtoReflect.Descriptor instead.
+func (*ModifyPositionResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{8}
 }
 
-type SetBalanceRequest struct {
+type ClosePositionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId int32   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Sum    float32 `protobuf:"fixed32,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	PositionId int32 `protobuf:"varint,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	Count      int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"` // 0 closes the whole position
 }
 
-func (x *SetBalanceRequest) Reset() {
-	*x = SetBalanceRequest{}
+func (x *ClosePositionRequest) Reset() {
+	*x = ClosePositionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[8]
+		mi := &file_protocol_broker_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetBalanceRequest) String() string {
+func (x *ClosePositionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetBalanceRequest) ProtoMessage() {}
+func (*ClosePositionRequest) ProtoMessage() {}
 
-func (x *SetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[8]
+func (x *ClosePositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,48 +735,50 @@ func (x *SetBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetBalanceRequest.ProtoReflect.Descriptor instead.
-func (*SetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{8}
-}
+// Deprecated: Use ClosePositionRequest.ProtoReflect.Descriptor instead.
+func (*ClosePositionRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{9}
+}
 
-func (x *SetBalanceRequest) GetUserId() int32 {
+func (x *ClosePositionRequest) GetPositionId() int32 {
 	if x != nil {
-		return x.UserId
+		return x.PositionId
 	}
 	return 0
 }
 
-func (x *SetBalanceRequest) GetSum() float32 {
+func (x *ClosePositionRequest) GetCount() int32 {
 	if x != nil {
-		return x.Sum
+		return x.Count
 	}
 	return 0
 }
 
-type SetBalanceResponse struct {
+type ClosePositionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	PositionId int32 `protobuf:"varint,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"` // id of the closed part, it differs from the requested id after a partial close
 }
 
-func (x *SetBalanceResponse) Reset() {
-	*x = SetBalanceResponse{}
+func (x *ClosePositionResponse) Reset() {
+	*x = ClosePositionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[9]
+		mi := &file_protocol_broker_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetBalanceResponse) String() string {
+func (x *ClosePositionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetBalanceResponse) ProtoMessage() {}
+func (*ClosePositionResponse) ProtoMessage() {}
 
-func (x *SetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[9]
+func (x *ClosePositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,36 +789,48 @@ func (x *SetBalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetBalanceResponse.ProtoReflect.Descriptor instead.
-func (*SetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ClosePositionResponse.ProtoReflect.Descriptor instead.
+func (*ClosePositionResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{10}
 }
 
-type GetBalanceRequest struct {
+func (x *ClosePositionResponse) GetPositionId() int32 {
+	if x != nil {
+		return x.PositionId
+	}
+	return 0
+}
+
+type PlaceLimitOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId int32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SymbolId   int32  `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	Price      *Money `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Count      int32  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	StopLoss   *Money `protobuf:"bytes,5,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit *Money `protobuf:"bytes,6,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	IsBuy      bool   `protobuf:"varint,7,opt,name=is_buy,json=isBuy,proto3" json:"is_buy,omitempty"`
 }
 
-func (x *GetBalanceRequest) Reset() {
-	*x = GetBalanceRequest{}
+func (x *PlaceLimitOrderRequest) Reset() {
+	*x = PlaceLimitOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[10]
+		mi := &file_protocol_broker_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetBalanceRequest) String() string {
+func (x *PlaceLimitOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceRequest) ProtoMessage() {}
+func (*PlaceLimitOrderRequest) ProtoMessage() {}
 
-func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[10]
+func (x *PlaceLimitOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,43 +841,78 @@ func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
-func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use PlaceLimitOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceLimitOrderRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetBalanceRequest) GetUserId() int32 {
+func (x *PlaceLimitOrderRequest) GetSymbolId() int32 {
 	if x != nil {
-		return x.UserId
+		return x.SymbolId
 	}
 	return 0
 }
 
-type GetBalanceResponse struct {
+func (x *PlaceLimitOrderRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PlaceLimitOrderRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PlaceLimitOrderRequest) GetStopLoss() *Money {
+	if x != nil {
+		return x.StopLoss
+	}
+	return nil
+}
+
+func (x *PlaceLimitOrderRequest) GetTakeProfit() *Money {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return nil
+}
+
+func (x *PlaceLimitOrderRequest) GetIsBuy() bool {
+	if x != nil {
+		return x.IsBuy
+	}
+	return false
+}
+
+type PlaceLimitOrderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sum float32 `protobuf:"fixed32,1,opt,name=sum,proto3" json:"sum,omitempty"`
+	OrderId int32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 }
 
-func (x *GetBalanceResponse) Reset() {
-	*x = GetBalanceResponse{}
+func (x *PlaceLimitOrderResponse) Reset() {
+	*x = PlaceLimitOrderResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocol_broker_proto_msgTypes[11]
+		mi := &file_protocol_broker_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetBalanceResponse) String() string {
+func (x *PlaceLimitOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceResponse) ProtoMessage() {}
+func (*PlaceLimitOrderResponse) ProtoMessage() {}
 
-func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocol_broker_proto_msgTypes[11]
+func (x *PlaceLimitOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,178 +923,2842 @@ func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
-func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_protocol_broker_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use PlaceLimitOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceLimitOrderResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetBalanceResponse) GetSum() float32 {
+func (x *PlaceLimitOrderResponse) GetOrderId() int32 {
 	if x != nil {
-		return x.Sum
+		return x.OrderId
 	}
 	return 0
 }
 
-var File_protocol_broker_proto protoreflect.FileDescriptor
+type PlaceOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_protocol_broker_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x67, 0x72, 0x70, 0x63, 0x22, 0x29,
-	0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
-	0x52, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x22, 0x29, 0x0a, 0x0e, 0x53, 0x69, 0x67,
-	0x6e, 0x55, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x10,
-	0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0xcc, 0x01, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x70,
-	0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74,
-	0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x73,
-	0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f,
-	0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x74, 0x61,
-	0x6b, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62,
-	0x75, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x75, 0x79, 0x22,
-	0x37, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x14, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49,
-	0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3e, 0x0a, 0x11, 0x53, 0x65,
-	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65,
-	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x2c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x26,
-	0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x32, 0x9d, 0x03, 0x0a, 0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65,
-	0x72, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x12, 0x14, 0x2e, 0x70, 0x67,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x69,
-	0x67, 0x6e, 0x49, 0x6e, 0x12, 0x14, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67,
-	0x6e, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x67, 0x72,
-	0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c,
-	0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1b, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70,
-	0x67, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a,
-	0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x67, 0x72,
-	0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74,
-	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x18, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x67, 0x72, 0x70,
-	0x63, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x75, 0x63, 0x6b, 0x79, 0x2d, 0x31, 0x2f, 0x62, 0x72,
-	0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	SymbolId   int32     `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	OrderType  OrderType `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=pgrpc.OrderType" json:"order_type,omitempty"`
+	Price      *Money    `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Count      int32     `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	StopLoss   *Money    `protobuf:"bytes,6,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit *Money    `protobuf:"bytes,7,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	IsBuy      bool      `protobuf:"varint,8,opt,name=is_buy,json=isBuy,proto3" json:"is_buy,omitempty"`
 }
 
-var (
-	file_protocol_broker_proto_rawDescOnce sync.Once
-	file_protocol_broker_proto_rawDescData = file_protocol_broker_proto_rawDesc
-)
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_protocol_broker_proto_rawDescGZIP() []byte {
-	file_protocol_broker_proto_rawDescOnce.Do(func() {
-		file_protocol_broker_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocol_broker_proto_rawDescData)
-	})
-	return file_protocol_broker_proto_rawDescData
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_protocol_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_protocol_broker_proto_goTypes = []interface{}{
-	(*SignUpRequest)(nil),         // 0: pgrpc.SignUpRequest
-	(*SignUpResponse)(nil),        // 1: pgrpc.SignUpResponse
-	(*SignInRequest)(nil),         // 2: pgrpc.SignInRequest
-	(*SignInResponse)(nil),        // 3: pgrpc.SignInResponse
-	(*OpenPositionRequest)(nil),   // 4: pgrpc.OpenPositionRequest
-	(*OpenPositionResponse)(nil),  // 5: pgrpc.OpenPositionResponse
-	(*ClosePositionRequest)(nil),  // 6: pgrpc.ClosePositionRequest
-	(*ClosePositionResponse)(nil), // 7: pgrpc.ClosePositionResponse
-	(*SetBalanceRequest)(nil),     // 8: pgrpc.SetBalanceRequest
-	(*SetBalanceResponse)(nil),    // 9: pgrpc.SetBalanceResponse
-	(*GetBalanceRequest)(nil),     // 10: pgrpc.GetBalanceRequest
-	(*GetBalanceResponse)(nil),    // 11: pgrpc.GetBalanceResponse
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_protocol_broker_proto_depIdxs = []int32{
-	0,  // 0: pgrpc.Broker.SignUp:input_type -> pgrpc.SignUpRequest
-	2,  // 1: pgrpc.Broker.SignIn:input_type -> pgrpc.SignInRequest
-	4,  // 2: pgrpc.Broker.OpenPosition:input_type -> pgrpc.OpenPositionRequest
-	6,  // 3: pgrpc.Broker.ClosePosition:input_type -> pgrpc.ClosePositionRequest
-	8,  // 4: pgrpc.Broker.SetBalance:input_type -> pgrpc.SetBalanceRequest
-	10, // 5: pgrpc.Broker.GetBalance:input_type -> pgrpc.GetBalanceRequest
-	1,  // 6: pgrpc.Broker.SignUp:output_type -> pgrpc.SignUpResponse
-	3,  // 7: pgrpc.Broker.SignIn:output_type -> pgrpc.SignInResponse
-	5,  // 8: pgrpc.Broker.OpenPosition:output_type -> pgrpc.OpenPositionResponse
-	7,  // 9: pgrpc.Broker.ClosePosition:output_type -> pgrpc.ClosePositionResponse
-	9,  // 10: pgrpc.Broker.SetBalance:output_type -> pgrpc.SetBalanceResponse
-	11, // 11: pgrpc.Broker.GetBalance:output_type -> pgrpc.GetBalanceResponse
-	6,  // [6:12] is the sub-list for method output_type
-	0,  // [0:6] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{13}
 }
 
-func init() { file_protocol_broker_proto_init() }
-func file_protocol_broker_proto_init() {
-	if File_protocol_broker_proto != nil {
-		return
+func (x *PlaceOrderRequest) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_protocol_broker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignUpRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_LIMIT
+}
+
+func (x *PlaceOrderRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetStopLoss() *Money {
+	if x != nil {
+		return x.StopLoss
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetTakeProfit() *Money {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetIsBuy() bool {
+	if x != nil {
+		return x.IsBuy
+	}
+	return false
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId int32 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_protocol_broker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignUpResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlaceOrderResponse) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type SetBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sum *Money `protobuf:"bytes,3,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *SetBalanceRequest) Reset() {
+	*x = SetBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBalanceRequest) ProtoMessage() {}
+
+func (x *SetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_protocol_broker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInRequest); i {
-			case 0:
-				return &v.state
-			case 1:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*SetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetBalanceRequest) GetSum() *Money {
+	if x != nil {
+		return x.Sum
+	}
+	return nil
+}
+
+type SetBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetBalanceResponse) Reset() {
+	*x = SetBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBalanceResponse) ProtoMessage() {}
+
+func (x *SetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*SetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{16}
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{17}
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sum *Money `protobuf:"bytes,2,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetBalanceResponse) GetSum() *Money {
+	if x != nil {
+		return x.Sum
+	}
+	return nil
+}
+
+type SubscribePricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolIds []int32 `protobuf:"varint,1,rep,packed,name=symbol_ids,json=symbolIds,proto3" json:"symbol_ids,omitempty"`
+}
+
+func (x *SubscribePricesRequest) Reset() {
+	*x = SubscribePricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribePricesRequest) ProtoMessage() {}
+
+func (x *SubscribePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribePricesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribePricesRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SubscribePricesRequest) GetSymbolIds() []int32 {
+	if x != nil {
+		return x.SymbolIds
+	}
+	return nil
+}
+
+type Price struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolId int32  `protobuf:"varint,1,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	Bid      *Money `protobuf:"bytes,2,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask      *Money `protobuf:"bytes,3,opt,name=ask,proto3" json:"ask,omitempty"`
+	Time     int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Price) Reset() {
+	*x = Price{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Price) ProtoMessage() {}
+
+func (x *Price) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Price.ProtoReflect.Descriptor instead.
+func (*Price) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Price) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *Price) GetBid() *Money {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
+func (x *Price) GetAsk() *Money {
+	if x != nil {
+		return x.Ask
+	}
+	return nil
+}
+
+func (x *Price) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type StreamPositionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamPositionsRequest) Reset() {
+	*x = StreamPositionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPositionsRequest) ProtoMessage() {}
+
+func (x *StreamPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPositionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{21}
+}
+
+type PositionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PositionId    int32       `protobuf:"varint,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	SymbolId      int32       `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	PriceClose    *Money      `protobuf:"bytes,3,opt,name=price_close,json=priceClose,proto3" json:"price_close,omitempty"`
+	Pnl           *Money      `protobuf:"bytes,4,opt,name=pnl,proto3" json:"pnl,omitempty"`
+	Closed        bool        `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`
+	CloseReason   CloseReason `protobuf:"varint,6,opt,name=close_reason,json=closeReason,proto3,enum=pgrpc.CloseReason" json:"close_reason,omitempty"`
+	MarginCall    bool        `protobuf:"varint,7,opt,name=margin_call,json=marginCall,proto3" json:"margin_call,omitempty"`     // the warning that margin level is below the margin-call level, it has no position
+	MarginLevel   float64     `protobuf:"fixed64,8,opt,name=margin_level,json=marginLevel,proto3" json:"margin_level,omitempty"` // margin level of the account in percent, set for the warning
+	OrderId       int32       `protobuf:"varint,9,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderRejected bool        `protobuf:"varint,10,opt,name=order_rejected,json=orderRejected,proto3" json:"order_rejected,omitempty"` // the pending order can never be filled and has been rejected, it has no position
+	RejectReason  string      `protobuf:"bytes,11,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+}
+
+func (x *PositionEvent) Reset() {
+	*x = PositionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PositionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionEvent) ProtoMessage() {}
+
+func (x *PositionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionEvent.ProtoReflect.Descriptor instead.
+func (*PositionEvent) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PositionEvent) GetPositionId() int32 {
+	if x != nil {
+		return x.PositionId
+	}
+	return 0
+}
+
+func (x *PositionEvent) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *PositionEvent) GetPriceClose() *Money {
+	if x != nil {
+		return x.PriceClose
+	}
+	return nil
+}
+
+func (x *PositionEvent) GetPnl() *Money {
+	if x != nil {
+		return x.Pnl
+	}
+	return nil
+}
+
+func (x *PositionEvent) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *PositionEvent) GetCloseReason() CloseReason {
+	if x != nil {
+		return x.CloseReason
+	}
+	return CloseReason_MANUAL
+}
+
+func (x *PositionEvent) GetMarginCall() bool {
+	if x != nil {
+		return x.MarginCall
+	}
+	return false
+}
+
+func (x *PositionEvent) GetMarginLevel() float64 {
+	if x != nil {
+		return x.MarginLevel
+	}
+	return 0
+}
+
+func (x *PositionEvent) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PositionEvent) GetOrderRejected() bool {
+	if x != nil {
+		return x.OrderRejected
+	}
+	return false
+}
+
+func (x *PositionEvent) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+type GetAccountHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From       int64       `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`                                                           // unix seconds, 0 means from the first entry
+	To         int64       `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`                                                               // unix seconds, exclusive, 0 means up to now
+	SymbolId   int32       `protobuf:"varint,4,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`                                   // 0 means all symbols
+	EntryTypes []EntryType `protobuf:"varint,5,rep,packed,name=entry_types,json=entryTypes,proto3,enum=pgrpc.EntryType" json:"entry_types,omitempty"` // empty means all types
+	PageSize   int32       `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                   // 0 means 50, at most 500
+	PageToken  int32       `protobuf:"varint,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                                // next_page_token of the previous page, 0 for the first page
+}
+
+func (x *GetAccountHistoryRequest) Reset() {
+	*x = GetAccountHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountHistoryRequest) ProtoMessage() {}
+
+func (x *GetAccountHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetAccountHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetAccountHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetAccountHistoryRequest) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *GetAccountHistoryRequest) GetEntryTypes() []EntryType {
+	if x != nil {
+		return x.EntryTypes
+	}
+	return nil
+}
+
+func (x *GetAccountHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAccountHistoryRequest) GetPageToken() int32 {
+	if x != nil {
+		return x.PageToken
+	}
+	return 0
+}
+
+type AccountEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntryId     int32     `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	EntryType   EntryType `protobuf:"varint,2,opt,name=entry_type,json=entryType,proto3,enum=pgrpc.EntryType" json:"entry_type,omitempty"`
+	Amount      *Money    `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Time        int64     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	PositionId  int32     `protobuf:"varint,5,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"` // 0 if the entry isn't linked to a position
+	OrderId     int32     `protobuf:"varint,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`          // 0 if the entry isn't linked to an order
+	SymbolId    int32     `protobuf:"varint,7,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	SymbolTitle string    `protobuf:"bytes,8,opt,name=symbol_title,json=symbolTitle,proto3" json:"symbol_title,omitempty"`
+	Pnl         *Money    `protobuf:"bytes,9,opt,name=pnl,proto3" json:"pnl,omitempty"` // realized pnl of the closed position
+}
+
+func (x *AccountEntry) Reset() {
+	*x = AccountEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountEntry) ProtoMessage() {}
+
+func (x *AccountEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountEntry.ProtoReflect.Descriptor instead.
+func (*AccountEntry) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AccountEntry) GetEntryId() int32 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *AccountEntry) GetEntryType() EntryType {
+	if x != nil {
+		return x.EntryType
+	}
+	return EntryType_DEPOSIT
+}
+
+func (x *AccountEntry) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *AccountEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AccountEntry) GetPositionId() int32 {
+	if x != nil {
+		return x.PositionId
+	}
+	return 0
+}
+
+func (x *AccountEntry) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AccountEntry) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *AccountEntry) GetSymbolTitle() string {
+	if x != nil {
+		return x.SymbolTitle
+	}
+	return ""
+}
+
+func (x *AccountEntry) GetPnl() *Money {
+	if x != nil {
+		return x.Pnl
+	}
+	return nil
+}
+
+type GetAccountHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries       []*AccountEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`                                     // the newest first
+	NextPageToken int32           `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 0 if it is the last page
+}
+
+func (x *GetAccountHistoryResponse) Reset() {
+	*x = GetAccountHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountHistoryResponse) ProtoMessage() {}
+
+func (x *GetAccountHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAccountHistoryResponse) GetEntries() []*AccountEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAccountHistoryResponse) GetNextPageToken() int32 {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return 0
+}
+
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PositionId   int32       `protobuf:"varint,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	SymbolId     int32       `protobuf:"varint,2,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	SymbolTitle  string      `protobuf:"bytes,3,opt,name=symbol_title,json=symbolTitle,proto3" json:"symbol_title,omitempty"`
+	Count        int32       `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	IsBuy        bool        `protobuf:"varint,5,opt,name=is_buy,json=isBuy,proto3" json:"is_buy,omitempty"`
+	PriceOpen    *Money      `protobuf:"bytes,6,opt,name=price_open,json=priceOpen,proto3" json:"price_open,omitempty"`
+	TimeOpen     int64       `protobuf:"varint,7,opt,name=time_open,json=timeOpen,proto3" json:"time_open,omitempty"`
+	StopLoss     *Money      `protobuf:"bytes,8,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit   *Money      `protobuf:"bytes,9,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	TrailingStop *Money      `protobuf:"bytes,10,opt,name=trailing_stop,json=trailingStop,proto3" json:"trailing_stop,omitempty"`
+	PriceClose   *Money      `protobuf:"bytes,11,opt,name=price_close,json=priceClose,proto3" json:"price_close,omitempty"` // the current close price of the open position
+	TimeClose    int64       `protobuf:"varint,12,opt,name=time_close,json=timeClose,proto3" json:"time_close,omitempty"`   // 0 for the open position
+	Pnl          *Money      `protobuf:"bytes,13,opt,name=pnl,proto3" json:"pnl,omitempty"`                                 // the current pnl of the open position or the realized pnl of the closed one
+	CloseReason  CloseReason `protobuf:"varint,14,opt,name=close_reason,json=closeReason,proto3,enum=pgrpc.CloseReason" json:"close_reason,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Position) GetPositionId() int32 {
+	if x != nil {
+		return x.PositionId
+	}
+	return 0
+}
+
+func (x *Position) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *Position) GetSymbolTitle() string {
+	if x != nil {
+		return x.SymbolTitle
+	}
+	return ""
+}
+
+func (x *Position) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Position) GetIsBuy() bool {
+	if x != nil {
+		return x.IsBuy
+	}
+	return false
+}
+
+func (x *Position) GetPriceOpen() *Money {
+	if x != nil {
+		return x.PriceOpen
+	}
+	return nil
+}
+
+func (x *Position) GetTimeOpen() int64 {
+	if x != nil {
+		return x.TimeOpen
+	}
+	return 0
+}
+
+func (x *Position) GetStopLoss() *Money {
+	if x != nil {
+		return x.StopLoss
+	}
+	return nil
+}
+
+func (x *Position) GetTakeProfit() *Money {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return nil
+}
+
+func (x *Position) GetTrailingStop() *Money {
+	if x != nil {
+		return x.TrailingStop
+	}
+	return nil
+}
+
+func (x *Position) GetPriceClose() *Money {
+	if x != nil {
+		return x.PriceClose
+	}
+	return nil
+}
+
+func (x *Position) GetTimeClose() int64 {
+	if x != nil {
+		return x.TimeClose
+	}
+	return 0
+}
+
+func (x *Position) GetPnl() *Money {
+	if x != nil {
+		return x.Pnl
+	}
+	return nil
+}
+
+func (x *Position) GetCloseReason() CloseReason {
+	if x != nil {
+		return x.CloseReason
+	}
+	return CloseReason_MANUAL
+}
+
+type ListPositionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`    // 0 means 50, at most 500
+	PageToken int32 `protobuf:"varint,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, 0 for the first page
+}
+
+func (x *ListPositionsRequest) Reset() {
+	*x = ListPositionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsRequest) ProtoMessage() {}
+
+func (x *ListPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListPositionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPositionsRequest) GetPageToken() int32 {
+	if x != nil {
+		return x.PageToken
+	}
+	return 0
+}
+
+type ListPositionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Positions     []*Position `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`                                 // open positions in order of opening, closed ones from the last closed
+	NextPageToken int32       `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 0 if it is the last page
+}
+
+func (x *ListPositionsResponse) Reset() {
+	*x = ListPositionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPositionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsResponse) ProtoMessage() {}
+
+func (x *ListPositionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPositionsResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListPositionsResponse) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+func (x *ListPositionsResponse) GetNextPageToken() int32 {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return 0
+}
+
+type AddSymbolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker   string `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Title    string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	LotSize  int32  `protobuf:"varint,3,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	TickSize *Money `protobuf:"bytes,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	Currency string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *AddSymbolRequest) Reset() {
+	*x = AddSymbolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSymbolRequest) ProtoMessage() {}
+
+func (x *AddSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSymbolRequest.ProtoReflect.Descriptor instead.
+func (*AddSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddSymbolRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *AddSymbolRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddSymbolRequest) GetLotSize() int32 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *AddSymbolRequest) GetTickSize() *Money {
+	if x != nil {
+		return x.TickSize
+	}
+	return nil
+}
+
+func (x *AddSymbolRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type AddSymbolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolId int32 `protobuf:"varint,1,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+}
+
+func (x *AddSymbolResponse) Reset() {
+	*x = AddSymbolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddSymbolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSymbolResponse) ProtoMessage() {}
+
+func (x *AddSymbolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSymbolResponse.ProtoReflect.Descriptor instead.
+func (*AddSymbolResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AddSymbolResponse) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+type DisableSymbolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolId int32 `protobuf:"varint,1,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+}
+
+func (x *DisableSymbolRequest) Reset() {
+	*x = DisableSymbolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisableSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableSymbolRequest) ProtoMessage() {}
+
+func (x *DisableSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableSymbolRequest.ProtoReflect.Descriptor instead.
+func (*DisableSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DisableSymbolRequest) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+type DisableSymbolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DisableSymbolResponse) Reset() {
+	*x = DisableSymbolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisableSymbolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableSymbolResponse) ProtoMessage() {}
+
+func (x *DisableSymbolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableSymbolResponse.ProtoReflect.Descriptor instead.
+func (*DisableSymbolResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{32}
+}
+
+type Symbol struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolId int32  `protobuf:"varint,1,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+	Ticker   string `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Title    string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Tradable bool   `protobuf:"varint,4,opt,name=tradable,proto3" json:"tradable,omitempty"`
+	LotSize  int32  `protobuf:"varint,5,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`   // count of a position is a multiple of it
+	TickSize *Money `protobuf:"bytes,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"` // prices and levels are multiples of it
+	Currency string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *Symbol) Reset() {
+	*x = Symbol{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Symbol) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Symbol) ProtoMessage() {}
+
+func (x *Symbol) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Symbol.ProtoReflect.Descriptor instead.
+func (*Symbol) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Symbol) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+func (x *Symbol) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Symbol) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Symbol) GetTradable() bool {
+	if x != nil {
+		return x.Tradable
+	}
+	return false
+}
+
+func (x *Symbol) GetLotSize() int32 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *Symbol) GetTickSize() *Money {
+	if x != nil {
+		return x.TickSize
+	}
+	return nil
+}
+
+func (x *Symbol) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListSymbolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSymbolsRequest) Reset() {
+	*x = ListSymbolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSymbolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSymbolsRequest) ProtoMessage() {}
+
+func (x *ListSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*ListSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{34}
+}
+
+type ListSymbolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbols []*Symbol `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+}
+
+func (x *ListSymbolsResponse) Reset() {
+	*x = ListSymbolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSymbolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSymbolsResponse) ProtoMessage() {}
+
+func (x *ListSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*ListSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListSymbolsResponse) GetSymbols() []*Symbol {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SymbolId int32 `protobuf:"varint,1,opt,name=symbol_id,json=symbolId,proto3" json:"symbol_id,omitempty"`
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetQuoteRequest) GetSymbolId() int32 {
+	if x != nil {
+		return x.SymbolId
+	}
+	return 0
+}
+
+type GetMarginRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetMarginRequest) Reset() {
+	*x = GetMarginRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMarginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarginRequest) ProtoMessage() {}
+
+func (x *GetMarginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarginRequest.ProtoReflect.Descriptor instead.
+func (*GetMarginRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{37}
+}
+
+type GetMarginResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Leverage    int32   `protobuf:"varint,1,opt,name=leverage,proto3" json:"leverage,omitempty"` // a new position reserves 1/leverage of its price as margin
+	Balance     *Money  `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Equity      *Money  `protobuf:"bytes,3,opt,name=equity,proto3" json:"equity,omitempty"` // the balance with pnl of open positions
+	UsedMargin  *Money  `protobuf:"bytes,4,opt,name=used_margin,json=usedMargin,proto3" json:"used_margin,omitempty"`
+	FreeMargin  *Money  `protobuf:"bytes,5,opt,name=free_margin,json=freeMargin,proto3" json:"free_margin,omitempty"`      // the equity which new positions may reserve
+	MarginLevel float64 `protobuf:"fixed64,6,opt,name=margin_level,json=marginLevel,proto3" json:"margin_level,omitempty"` // equity to used margin in percent, 0 without open positions
+}
+
+func (x *GetMarginResponse) Reset() {
+	*x = GetMarginResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMarginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarginResponse) ProtoMessage() {}
+
+func (x *GetMarginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarginResponse.ProtoReflect.Descriptor instead.
+func (*GetMarginResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetMarginResponse) GetLeverage() int32 {
+	if x != nil {
+		return x.Leverage
+	}
+	return 0
+}
+
+func (x *GetMarginResponse) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *GetMarginResponse) GetEquity() *Money {
+	if x != nil {
+		return x.Equity
+	}
+	return nil
+}
+
+func (x *GetMarginResponse) GetUsedMargin() *Money {
+	if x != nil {
+		return x.UsedMargin
+	}
+	return nil
+}
+
+func (x *GetMarginResponse) GetFreeMargin() *Money {
+	if x != nil {
+		return x.FreeMargin
+	}
+	return nil
+}
+
+func (x *GetMarginResponse) GetMarginLevel() float64 {
+	if x != nil {
+		return x.MarginLevel
+	}
+	return 0
+}
+
+type SetLeverageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   int32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Leverage int32 `protobuf:"varint,2,opt,name=leverage,proto3" json:"leverage,omitempty"` // open positions keep their margin
+}
+
+func (x *SetLeverageRequest) Reset() {
+	*x = SetLeverageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLeverageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLeverageRequest) ProtoMessage() {}
+
+func (x *SetLeverageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLeverageRequest.ProtoReflect.Descriptor instead.
+func (*SetLeverageRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetLeverageRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetLeverageRequest) GetLeverage() int32 {
+	if x != nil {
+		return x.Leverage
+	}
+	return 0
+}
+
+type SetLeverageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetLeverageResponse) Reset() {
+	*x = SetLeverageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLeverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLeverageResponse) ProtoMessage() {}
+
+func (x *SetLeverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLeverageResponse.ProtoReflect.Descriptor instead.
+func (*SetLeverageResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{40}
+}
+
+type GetEngineStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetEngineStatsRequest) Reset() {
+	*x = GetEngineStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEngineStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineStatsRequest) ProtoMessage() {}
+
+func (x *GetEngineStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetEngineStatsRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{41}
+}
+
+// GetEngineStatsResponse shows how the engine which closes positions by prices keeps up with them.
+// Counters are counted since the broker has started
+type GetEngineStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workers     int32 `protobuf:"varint,1,opt,name=workers,proto3" json:"workers,omitempty"`
+	Capacity    int32 `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`   // jobs which the queues of all workers may keep
+	Queued      int32 `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`       // jobs waiting in the queues now
+	Positions   int32 `protobuf:"varint,4,opt,name=positions,proto3" json:"positions,omitempty"` // open positions which the engine controls
+	Ticks       int64 `protobuf:"varint,5,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	Checked     int64 `protobuf:"varint,6,opt,name=checked,proto3" json:"checked,omitempty"`     // positions checked by prices
+	Submitted   int64 `protobuf:"varint,7,opt,name=submitted,proto3" json:"submitted,omitempty"` // closes by stop loss and take profit and margin controls
+	Done        int64 `protobuf:"varint,8,opt,name=done,proto3" json:"done,omitempty"`
+	Failed      int64 `protobuf:"varint,9,opt,name=failed,proto3" json:"failed,omitempty"`
+	Blocked     int64 `protobuf:"varint,10,opt,name=blocked,proto3" json:"blocked,omitempty"`                            // jobs which have waited for a full queue, prices are delayed meanwhile
+	BlockedTime int64 `protobuf:"varint,11,opt,name=blocked_time,json=blockedTime,proto3" json:"blocked_time,omitempty"` // milliseconds which the jobs have waited
+}
+
+func (x *GetEngineStatsResponse) Reset() {
+	*x = GetEngineStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEngineStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineStatsResponse) ProtoMessage() {}
+
+func (x *GetEngineStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetEngineStatsResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetEngineStatsResponse) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetPositions() int32 {
+	if x != nil {
+		return x.Positions
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetTicks() int64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetSubmitted() int64 {
+	if x != nil {
+		return x.Submitted
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetBlocked() int64 {
+	if x != nil {
+		return x.Blocked
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetBlockedTime() int64 {
+	if x != nil {
+		return x.BlockedTime
+	}
+	return 0
+}
+
+type GetPricerStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetPricerStatusRequest) Reset() {
+	*x = GetPricerStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPricerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricerStatusRequest) ProtoMessage() {}
+
+func (x *GetPricerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricerStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetPricerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{43}
+}
+
+// GetPricerStatusResponse describes the connection to the pricer which sends prices
+type GetPricerStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State      string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                        // connecting, connected or disconnected
+	Since      int64  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                       // unix seconds when the state has changed
+	LastTick   int64  `protobuf:"varint,3,opt,name=last_tick,json=lastTick,proto3" json:"last_tick,omitempty"` // unix seconds, 0 if no price has arrived
+	Reconnects int64  `protobuf:"varint,4,opt,name=reconnects,proto3" json:"reconnects,omitempty"`
+	Stale      bool   `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"` // no price has arrived for the stale time
+}
+
+func (x *GetPricerStatusResponse) Reset() {
+	*x = GetPricerStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_broker_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPricerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricerStatusResponse) ProtoMessage() {}
+
+func (x *GetPricerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_broker_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricerStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetPricerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_protocol_broker_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetPricerStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetPricerStatusResponse) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetPricerStatusResponse) GetLastTick() int64 {
+	if x != nil {
+		return x.LastTick
+	}
+	return 0
+}
+
+func (x *GetPricerStatusResponse) GetReconnects() int64 {
+	if x != nil {
+		return x.Reconnects
+	}
+	return 0
+}
+
+func (x *GetPricerStatusResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+var File_protocol_broker_proto protoreflect.FileDescriptor
+
+var file_protocol_broker_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x67, 0x72, 0x70, 0x63, 0x22, 0x33,
+	0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x61,
+	0x6e, 0x6f, 0x73, 0x22, 0x6f, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x26, 0x0a, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x02, 0x22, 0x29, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x47, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x3f, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e,
+	0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xa8, 0x02, 0x0a, 0x13, 0x4f, 0x70,
+	0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62, 0x75, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x75, 0x79, 0x12, 0x31, 0x0a, 0x0d, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79,
+	0x52, 0x0c, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x22,
+	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x65, 0x79, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x2d, 0x0a,
+	0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79,
+	0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04,
+	0x08, 0x06, 0x10, 0x07, 0x22, 0x37, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x98, 0x01,
+	0x0a, 0x15, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70,
+	0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x4c,
+	0x6f, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x18, 0x0a, 0x16, 0x4d, 0x6f, 0x64, 0x69,
+	0x66, 0x79, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x4d, 0x0a, 0x14, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x38, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xe6, 0x01, 0x0a, 0x16,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a,
+	0x09, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08,
+	0x73, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x6b,
+	0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62, 0x75,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x75, 0x79, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x02, 0x22, 0x34, 0x0a, 0x17, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x92, 0x02, 0x0a, 0x11, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x2f, 0x0a,
+	0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x10, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22,
+	0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70,
+	0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x4c,
+	0x6f, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62, 0x75, 0x79, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x75, 0x79, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22,
+	0x2f, 0x0a, 0x12, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0x3f, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79,
+	0x52, 0x03, 0x73, 0x75, 0x6d, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10,
+	0x03, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x22, 0x3a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x65, 0x79, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x37,
+	0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x22, 0x78, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x1e, 0x0a,
+	0x03, 0x62, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1e, 0x0a,
+	0x03, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x22, 0x1e, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x22, 0x96, 0x03, 0x0a, 0x0d, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
+	0x64, 0x12, 0x2d, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x03, 0x70, 0x6e, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x70, 0x6e, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x0c, 0x63, 0x6c, 0x6f, 0x73,
+	0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x43, 0x61, 0x6c, 0x6c,
+	0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xd0, 0x01, 0x0a, 0x18, 0x47,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0xb0, 0x02,
+	0x0a, 0x0c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x0a, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x1e, 0x0a, 0x03, 0x70, 0x6e, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x70, 0x6e, 0x6c,
+	0x22, 0x72, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x94, 0x04, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x62,
+	0x75, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x42, 0x75, 0x79, 0x12,
+	0x2b, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65,
+	0x79, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x74, 0x6f,
+	0x70, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70,
+	0x4c, 0x6f, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x74, 0x12, 0x31, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x5f,
+	0x73, 0x74, 0x6f, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x2d, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x43,
+	0x6c, 0x6f, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x70, 0x6e, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52,
+	0x03, 0x70, 0x6e, 0x6c, 0x12, 0x35, 0x0a, 0x0c, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0b,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x6e, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d,
+	0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa2, 0x01, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x6f, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x74, 0x69, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x30, 0x0a, 0x11, 0x41, 0x64,
+	0x64, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x14,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
+	0x64, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd1, 0x01, 0x0a, 0x06, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x74, 0x72, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6c, 0x6f, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x6c, 0x6f, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x74, 0x69, 0x63, 0x6b, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x14,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x07, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x22, 0x2e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x49, 0x64, 0x22, 0x18, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x67, 0x69,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0xfe,
+	0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x12, 0x26, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52,
+	0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x71, 0x75, 0x69,
+	0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x65, 0x71, 0x75, 0x69, 0x74, 0x79, 0x12, 0x2d,
+	0x0a, 0x0b, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65,
+	0x79, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x64, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x2d, 0x0a,
+	0x0b, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79,
+	0x52, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22,
+	0x49, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x65,
+	0x74, 0x4c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbb, 0x02, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c,
+	0x61, 0x73, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x2a, 0x37, 0x0a,
+	0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x49,
+	0x4d, 0x49, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x5f, 0x49, 0x46, 0x5f, 0x54, 0x4f, 0x55,
+	0x43, 0x48, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x55, 0x0a, 0x0b, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x4e, 0x55, 0x41, 0x4c, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x4f, 0x50, 0x5f, 0x4c, 0x4f, 0x53, 0x53, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x41, 0x4b, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x54, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x52, 0x47, 0x49, 0x4e, 0x5f, 0x43, 0x41, 0x4c, 0x4c,
+	0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x04, 0x2a, 0x65, 0x0a,
+	0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45,
+	0x50, 0x4f, 0x53, 0x49, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x49, 0x54, 0x48, 0x44,
+	0x52, 0x41, 0x57, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x4f, 0x53, 0x49, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4f,
+	0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x10, 0x03, 0x12, 0x0e,
+	0x0a, 0x0a, 0x41, 0x44, 0x4a, 0x55, 0x53, 0x54, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x05, 0x22, 0x04,
+	0x08, 0x04, 0x10, 0x04, 0x32, 0xd5, 0x09, 0x0a, 0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12,
+	0x37, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x12, 0x14, 0x2e, 0x70, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e,
+	0x49, 0x6e, 0x12, 0x14, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x49, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0f, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42,
+	0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x1d, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x58,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74,
+	0x4f, 0x70, 0x65, 0x6e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1b, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x19, 0x2e,
+	0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x12, 0x16, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x17, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x67,
+	0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x84, 0x03, 0x0a,
+	0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x40, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x12, 0x17, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1b, 0x2e, 0x70, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x44,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65,
+	0x74, 0x4c, 0x65, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x1c, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x68, 0x75, 0x63, 0x6b, 0x79, 0x2d, 0x31, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_protocol_broker_proto_rawDescOnce sync.Once
+	file_protocol_broker_proto_rawDescData = file_protocol_broker_proto_rawDesc
+)
+
+func file_protocol_broker_proto_rawDescGZIP() []byte {
+	file_protocol_broker_proto_rawDescOnce.Do(func() {
+		file_protocol_broker_proto_rawDescData = protoimpl.X.CompressGZIP(file_protocol_broker_proto_rawDescData)
+	})
+	return file_protocol_broker_proto_rawDescData
+}
+
+var file_protocol_broker_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_protocol_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_protocol_broker_proto_goTypes = []interface{}{
+	(OrderType)(0),                    // 0: pgrpc.OrderType
+	(CloseReason)(0),                  // 1: pgrpc.CloseReason
+	(EntryType)(0),                    // 2: pgrpc.EntryType
+	(*Money)(nil),                     // 3: pgrpc.Money
+	(*SignUpRequest)(nil),             // 4: pgrpc.SignUpRequest
+	(*SignUpResponse)(nil),            // 5: pgrpc.SignUpResponse
+	(*SignInRequest)(nil),             // 6: pgrpc.SignInRequest
+	(*SignInResponse)(nil),            // 7: pgrpc.SignInResponse
+	(*OpenPositionRequest)(nil),       // 8: pgrpc.OpenPositionRequest
+	(*OpenPositionResponse)(nil),      // 9: pgrpc.OpenPositionResponse
+	(*ModifyPositionRequest)(nil),     // 10: pgrpc.ModifyPositionRequest
+	(*ModifyPositionResponse)(nil),    // 11: pgrpc.ModifyPositionResponse
+	(*ClosePositionRequest)(nil),      // 12: pgrpc.ClosePositionRequest
+	(*ClosePositionResponse)(nil),     // 13: pgrpc.ClosePositionResponse
+	(*PlaceLimitOrderRequest)(nil),    // 14: pgrpc.PlaceLimitOrderRequest
+	(*PlaceLimitOrderResponse)(nil),   // 15: pgrpc.PlaceLimitOrderResponse
+	(*PlaceOrderRequest)(nil),         // 16: pgrpc.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),        // 17: pgrpc.PlaceOrderResponse
+	(*SetBalanceRequest)(nil),         // 18: pgrpc.SetBalanceRequest
+	(*SetBalanceResponse)(nil),        // 19: pgrpc.SetBalanceResponse
+	(*GetBalanceRequest)(nil),         // 20: pgrpc.GetBalanceRequest
+	(*GetBalanceResponse)(nil),        // 21: pgrpc.GetBalanceResponse
+	(*SubscribePricesRequest)(nil),    // 22: pgrpc.SubscribePricesRequest
+	(*Price)(nil),                     // 23: pgrpc.Price
+	(*StreamPositionsRequest)(nil),    // 24: pgrpc.StreamPositionsRequest
+	(*PositionEvent)(nil),             // 25: pgrpc.PositionEvent
+	(*GetAccountHistoryRequest)(nil),  // 26: pgrpc.GetAccountHistoryRequest
+	(*AccountEntry)(nil),              // 27: pgrpc.AccountEntry
+	(*GetAccountHistoryResponse)(nil), // 28: pgrpc.GetAccountHistoryResponse
+	(*Position)(nil),                  // 29: pgrpc.Position
+	(*ListPositionsRequest)(nil),      // 30: pgrpc.ListPositionsRequest
+	(*ListPositionsResponse)(nil),     // 31: pgrpc.ListPositionsResponse
+	(*AddSymbolRequest)(nil),          // 32: pgrpc.AddSymbolRequest
+	(*AddSymbolResponse)(nil),         // 33: pgrpc.AddSymbolResponse
+	(*DisableSymbolRequest)(nil),      // 34: pgrpc.DisableSymbolRequest
+	(*DisableSymbolResponse)(nil),     // 35: pgrpc.DisableSymbolResponse
+	(*Symbol)(nil),                    // 36: pgrpc.Symbol
+	(*ListSymbolsRequest)(nil),        // 37: pgrpc.ListSymbolsRequest
+	(*ListSymbolsResponse)(nil),       // 38: pgrpc.ListSymbolsResponse
+	(*GetQuoteRequest)(nil),           // 39: pgrpc.GetQuoteRequest
+	(*GetMarginRequest)(nil),          // 40: pgrpc.GetMarginRequest
+	(*GetMarginResponse)(nil),         // 41: pgrpc.GetMarginResponse
+	(*SetLeverageRequest)(nil),        // 42: pgrpc.SetLeverageRequest
+	(*SetLeverageResponse)(nil),       // 43: pgrpc.SetLeverageResponse
+	(*GetEngineStatsRequest)(nil),     // 44: pgrpc.GetEngineStatsRequest
+	(*GetEngineStatsResponse)(nil),    // 45: pgrpc.GetEngineStatsResponse
+	(*GetPricerStatusRequest)(nil),    // 46: pgrpc.GetPricerStatusRequest
+	(*GetPricerStatusResponse)(nil),   // 47: pgrpc.GetPricerStatusResponse
+}
+var file_protocol_broker_proto_depIdxs = []int32{
+	3,  // 0: pgrpc.SignUpRequest.deposit:type_name -> pgrpc.Money
+	3,  // 1: pgrpc.OpenPositionRequest.trailing_stop:type_name -> pgrpc.Money
+	3,  // 2: pgrpc.OpenPositionRequest.price:type_name -> pgrpc.Money
+	3,  // 3: pgrpc.OpenPositionRequest.stop_loss:type_name -> pgrpc.Money
+	3,  // 4: pgrpc.OpenPositionRequest.take_profit:type_name -> pgrpc.Money
+	3,  // 5: pgrpc.ModifyPositionRequest.stop_loss:type_name -> pgrpc.Money
+	3,  // 6: pgrpc.ModifyPositionRequest.take_profit:type_name -> pgrpc.Money
+	3,  // 7: pgrpc.PlaceLimitOrderRequest.price:type_name -> pgrpc.Money
+	3,  // 8: pgrpc.PlaceLimitOrderRequest.stop_loss:type_name -> pgrpc.Money
+	3,  // 9: pgrpc.PlaceLimitOrderRequest.take_profit:type_name -> pgrpc.Money
+	0,  // 10: pgrpc.PlaceOrderRequest.order_type:type_name -> pgrpc.OrderType
+	3,  // 11: pgrpc.PlaceOrderRequest.price:type_name -> pgrpc.Money
+	3,  // 12: pgrpc.PlaceOrderRequest.stop_loss:type_name -> pgrpc.Money
+	3,  // 13: pgrpc.PlaceOrderRequest.take_profit:type_name -> pgrpc.Money
+	3,  // 14: pgrpc.SetBalanceRequest.sum:type_name -> pgrpc.Money
+	3,  // 15: pgrpc.GetBalanceResponse.sum:type_name -> pgrpc.Money
+	3,  // 16: pgrpc.Price.bid:type_name -> pgrpc.Money
+	3,  // 17: pgrpc.Price.ask:type_name -> pgrpc.Money
+	3,  // 18: pgrpc.PositionEvent.price_close:type_name -> pgrpc.Money
+	3,  // 19: pgrpc.PositionEvent.pnl:type_name -> pgrpc.Money
+	1,  // 20: pgrpc.PositionEvent.close_reason:type_name -> pgrpc.CloseReason
+	2,  // 21: pgrpc.GetAccountHistoryRequest.entry_types:type_name -> pgrpc.EntryType
+	2,  // 22: pgrpc.AccountEntry.entry_type:type_name -> pgrpc.EntryType
+	3,  // 23: pgrpc.AccountEntry.amount:type_name -> pgrpc.Money
+	3,  // 24: pgrpc.AccountEntry.pnl:type_name -> pgrpc.Money
+	27, // 25: pgrpc.GetAccountHistoryResponse.entries:type_name -> pgrpc.AccountEntry
+	3,  // 26: pgrpc.Position.price_open:type_name -> pgrpc.Money
+	3,  // 27: pgrpc.Position.stop_loss:type_name -> pgrpc.Money
+	3,  // 28: pgrpc.Position.take_profit:type_name -> pgrpc.Money
+	3,  // 29: pgrpc.Position.trailing_stop:type_name -> pgrpc.Money
+	3,  // 30: pgrpc.Position.price_close:type_name -> pgrpc.Money
+	3,  // 31: pgrpc.Position.pnl:type_name -> pgrpc.Money
+	1,  // 32: pgrpc.Position.close_reason:type_name -> pgrpc.CloseReason
+	29, // 33: pgrpc.ListPositionsResponse.positions:type_name -> pgrpc.Position
+	3,  // 34: pgrpc.AddSymbolRequest.tick_size:type_name -> pgrpc.Money
+	3,  // 35: pgrpc.Symbol.tick_size:type_name -> pgrpc.Money
+	36, // 36: pgrpc.ListSymbolsResponse.symbols:type_name -> pgrpc.Symbol
+	3,  // 37: pgrpc.GetMarginResponse.balance:type_name -> pgrpc.Money
+	3,  // 38: pgrpc.GetMarginResponse.equity:type_name -> pgrpc.Money
+	3,  // 39: pgrpc.GetMarginResponse.used_margin:type_name -> pgrpc.Money
+	3,  // 40: pgrpc.GetMarginResponse.free_margin:type_name -> pgrpc.Money
+	4,  // 41: pgrpc.Broker.SignUp:input_type -> pgrpc.SignUpRequest
+	6,  // 42: pgrpc.Broker.SignIn:input_type -> pgrpc.SignInRequest
+	8,  // 43: pgrpc.Broker.OpenPosition:input_type -> pgrpc.OpenPositionRequest
+	10, // 44: pgrpc.Broker.ModifyPosition:input_type -> pgrpc.ModifyPositionRequest
+	12, // 45: pgrpc.Broker.ClosePosition:input_type -> pgrpc.ClosePositionRequest
+	14, // 46: pgrpc.Broker.PlaceLimitOrder:input_type -> pgrpc.PlaceLimitOrderRequest
+	16, // 47: pgrpc.Broker.PlaceOrder:input_type -> pgrpc.PlaceOrderRequest
+	18, // 48: pgrpc.Broker.SetBalance:input_type -> pgrpc.SetBalanceRequest
+	20, // 49: pgrpc.Broker.GetBalance:input_type -> pgrpc.GetBalanceRequest
+	22, // 50: pgrpc.Broker.SubscribePrices:input_type -> pgrpc.SubscribePricesRequest
+	24, // 51: pgrpc.Broker.StreamPositions:input_type -> pgrpc.StreamPositionsRequest
+	26, // 52: pgrpc.Broker.GetAccountHistory:input_type -> pgrpc.GetAccountHistoryRequest
+	30, // 53: pgrpc.Broker.ListOpenPositions:input_type -> pgrpc.ListPositionsRequest
+	30, // 54: pgrpc.Broker.ListClosedPositions:input_type -> pgrpc.ListPositionsRequest
+	37, // 55: pgrpc.Broker.ListSymbols:input_type -> pgrpc.ListSymbolsRequest
+	39, // 56: pgrpc.Broker.GetQuote:input_type -> pgrpc.GetQuoteRequest
+	40, // 57: pgrpc.Broker.GetMargin:input_type -> pgrpc.GetMarginRequest
+	32, // 58: pgrpc.Admin.AddSymbol:input_type -> pgrpc.AddSymbolRequest
+	34, // 59: pgrpc.Admin.DisableSymbol:input_type -> pgrpc.DisableSymbolRequest
+	42, // 60: pgrpc.Admin.SetLeverage:input_type -> pgrpc.SetLeverageRequest
+	44, // 61: pgrpc.Admin.GetEngineStats:input_type -> pgrpc.GetEngineStatsRequest
+	46, // 62: pgrpc.Admin.GetPricerStatus:input_type -> pgrpc.GetPricerStatusRequest
+	5,  // 63: pgrpc.Broker.SignUp:output_type -> pgrpc.SignUpResponse
+	7,  // 64: pgrpc.Broker.SignIn:output_type -> pgrpc.SignInResponse
+	9,  // 65: pgrpc.Broker.OpenPosition:output_type -> pgrpc.OpenPositionResponse
+	11, // 66: pgrpc.Broker.ModifyPosition:output_type -> pgrpc.ModifyPositionResponse
+	13, // 67: pgrpc.Broker.ClosePosition:output_type -> pgrpc.ClosePositionResponse
+	15, // 68: pgrpc.Broker.PlaceLimitOrder:output_type -> pgrpc.PlaceLimitOrderResponse
+	17, // 69: pgrpc.Broker.PlaceOrder:output_type -> pgrpc.PlaceOrderResponse
+	19, // 70: pgrpc.Broker.SetBalance:output_type -> pgrpc.SetBalanceResponse
+	21, // 71: pgrpc.Broker.GetBalance:output_type -> pgrpc.GetBalanceResponse
+	23, // 72: pgrpc.Broker.SubscribePrices:output_type -> pgrpc.Price
+	25, // 73: pgrpc.Broker.StreamPositions:output_type -> pgrpc.PositionEvent
+	28, // 74: pgrpc.Broker.GetAccountHistory:output_type -> pgrpc.GetAccountHistoryResponse
+	31, // 75: pgrpc.Broker.ListOpenPositions:output_type -> pgrpc.ListPositionsResponse
+	31, // 76: pgrpc.Broker.ListClosedPositions:output_type -> pgrpc.ListPositionsResponse
+	38, // 77: pgrpc.Broker.ListSymbols:output_type -> pgrpc.ListSymbolsResponse
+	23, // 78: pgrpc.Broker.GetQuote:output_type -> pgrpc.Price
+	41, // 79: pgrpc.Broker.GetMargin:output_type -> pgrpc.GetMarginResponse
+	33, // 80: pgrpc.Admin.AddSymbol:output_type -> pgrpc.AddSymbolResponse
+	35, // 81: pgrpc.Admin.DisableSymbol:output_type -> pgrpc.DisableSymbolResponse
+	43, // 82: pgrpc.Admin.SetLeverage:output_type -> pgrpc.SetLeverageResponse
+	45, // 83: pgrpc.Admin.GetEngineStats:output_type -> pgrpc.GetEngineStatsResponse
+	47, // 84: pgrpc.Admin.GetPricerStatus:output_type -> pgrpc.GetPricerStatusResponse
+	63, // [63:85] is the sub-list for method output_type
+	41, // [41:63] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
+}
+
+func init() { file_protocol_broker_proto_init() }
+func file_protocol_broker_proto_init() {
+	if File_protocol_broker_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protocol_broker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Money); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignUpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignUpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenPositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenPositionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifyPositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifyPositionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClosePositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClosePositionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceLimitOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceLimitOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
 				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
@@ -780,8 +3766,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInResponse); i {
+		file_protocol_broker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -792,8 +3778,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenPositionRequest); i {
+		file_protocol_broker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceOrderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -804,8 +3790,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenPositionResponse); i {
+		file_protocol_broker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetBalanceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -816,8 +3802,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClosePositionRequest); i {
+		file_protocol_broker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetBalanceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -828,8 +3814,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClosePositionResponse); i {
+		file_protocol_broker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -840,8 +3826,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetBalanceRequest); i {
+		file_protocol_broker_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -852,8 +3838,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetBalanceResponse); i {
+		file_protocol_broker_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribePricesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -864,8 +3850,8 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBalanceRequest); i {
+		file_protocol_broker_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Price); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,8 +3862,284 @@ func file_protocol_broker_proto_init() {
 				return nil
 			}
 		}
-		file_protocol_broker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBalanceResponse); i {
+		file_protocol_broker_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamPositionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PositionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPositionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPositionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddSymbolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddSymbolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableSymbolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableSymbolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Symbol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSymbolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSymbolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMarginRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMarginResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLeverageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLeverageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEngineStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEngineStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPricerStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocol_broker_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPricerStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,13 +4156,14 @@ func file_protocol_broker_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocol_broker_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   45,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_protocol_broker_proto_goTypes,
 		DependencyIndexes: file_protocol_broker_proto_depIdxs,
+		EnumInfos:         file_protocol_broker_proto_enumTypes,
 		MessageInfos:      file_protocol_broker_proto_msgTypes,
 	}.Build()
 	File_protocol_broker_proto = out.File
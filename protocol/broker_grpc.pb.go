@@ -21,9 +21,20 @@ type BrokerClient interface {
 	SignUp(ctx context.Context, in *SignUpRequest, opts ...grpc.CallOption) (*SignUpResponse, error)
 	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error)
 	OpenPosition(ctx context.Context, in *OpenPositionRequest, opts ...grpc.CallOption) (*OpenPositionResponse, error)
+	ModifyPosition(ctx context.Context, in *ModifyPositionRequest, opts ...grpc.CallOption) (*ModifyPositionResponse, error)
 	ClosePosition(ctx context.Context, in *ClosePositionRequest, opts ...grpc.CallOption) (*ClosePositionResponse, error)
+	PlaceLimitOrder(ctx context.Context, in *PlaceLimitOrderRequest, opts ...grpc.CallOption) (*PlaceLimitOrderResponse, error)
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
 	SetBalance(ctx context.Context, in *SetBalanceRequest, opts ...grpc.CallOption) (*SetBalanceResponse, error)
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	SubscribePrices(ctx context.Context, in *SubscribePricesRequest, opts ...grpc.CallOption) (Broker_SubscribePricesClient, error)
+	StreamPositions(ctx context.Context, in *StreamPositionsRequest, opts ...grpc.CallOption) (Broker_StreamPositionsClient, error)
+	GetAccountHistory(ctx context.Context, in *GetAccountHistoryRequest, opts ...grpc.CallOption) (*GetAccountHistoryResponse, error)
+	ListOpenPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error)
+	ListClosedPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error)
+	ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error)
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Price, error)
+	GetMargin(ctx context.Context, in *GetMarginRequest, opts ...grpc.CallOption) (*GetMarginResponse, error)
 }
 
 type brokerClient struct {
@@ -61,6 +72,15 @@ func (c *brokerClient) OpenPosition(ctx context.Context, in *OpenPositionRequest
 	return out, nil
 }
 
+func (c *brokerClient) ModifyPosition(ctx context.Context, in *ModifyPositionRequest, opts ...grpc.CallOption) (*ModifyPositionResponse, error) {
+	out := new(ModifyPositionResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/ModifyPosition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *brokerClient) ClosePosition(ctx context.Context, in *ClosePositionRequest, opts ...grpc.CallOption) (*ClosePositionResponse, error) {
 	out := new(ClosePositionResponse)
 	err := c.cc.Invoke(ctx, "/pgrpc.Broker/ClosePosition", in, out, opts...)
@@ -70,6 +90,24 @@ func (c *brokerClient) ClosePosition(ctx context.Context, in *ClosePositionReque
 	return out, nil
 }
 
+func (c *brokerClient) PlaceLimitOrder(ctx context.Context, in *PlaceLimitOrderRequest, opts ...grpc.CallOption) (*PlaceLimitOrderResponse, error) {
+	out := new(PlaceLimitOrderResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/PlaceLimitOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/PlaceOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *brokerClient) SetBalance(ctx context.Context, in *SetBalanceRequest, opts ...grpc.CallOption) (*SetBalanceResponse, error) {
 	out := new(SetBalanceResponse)
 	err := c.cc.Invoke(ctx, "/pgrpc.Broker/SetBalance", in, out, opts...)
@@ -88,6 +126,124 @@ func (c *brokerClient) GetBalance(ctx context.Context, in *GetBalanceRequest, op
 	return out, nil
 }
 
+func (c *brokerClient) SubscribePrices(ctx context.Context, in *SubscribePricesRequest, opts ...grpc.CallOption) (Broker_SubscribePricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Broker_ServiceDesc.Streams[0], "/pgrpc.Broker/SubscribePrices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &brokerSubscribePricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Broker_SubscribePricesClient interface {
+	Recv() (*Price, error)
+	grpc.ClientStream
+}
+
+type brokerSubscribePricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *brokerSubscribePricesClient) Recv() (*Price, error) {
+	m := new(Price)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *brokerClient) StreamPositions(ctx context.Context, in *StreamPositionsRequest, opts ...grpc.CallOption) (Broker_StreamPositionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Broker_ServiceDesc.Streams[1], "/pgrpc.Broker/StreamPositions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &brokerStreamPositionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Broker_StreamPositionsClient interface {
+	Recv() (*PositionEvent, error)
+	grpc.ClientStream
+}
+
+type brokerStreamPositionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *brokerStreamPositionsClient) Recv() (*PositionEvent, error) {
+	m := new(PositionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *brokerClient) GetAccountHistory(ctx context.Context, in *GetAccountHistoryRequest, opts ...grpc.CallOption) (*GetAccountHistoryResponse, error) {
+	out := new(GetAccountHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/GetAccountHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) ListOpenPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error) {
+	out := new(ListPositionsResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/ListOpenPositions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) ListClosedPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error) {
+	out := new(ListPositionsResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/ListClosedPositions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error) {
+	out := new(ListSymbolsResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/ListSymbols", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Price, error) {
+	out := new(Price)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/GetQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) GetMargin(ctx context.Context, in *GetMarginRequest, opts ...grpc.CallOption) (*GetMarginResponse, error) {
+	out := new(GetMarginResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Broker/GetMargin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BrokerServer is the server API for Broker service.
 // All implementations must embed UnimplementedBrokerServer
 // for forward compatibility
@@ -95,9 +251,20 @@ type BrokerServer interface {
 	SignUp(context.Context, *SignUpRequest) (*SignUpResponse, error)
 	SignIn(context.Context, *SignInRequest) (*SignInResponse, error)
 	OpenPosition(context.Context, *OpenPositionRequest) (*OpenPositionResponse, error)
+	ModifyPosition(context.Context, *ModifyPositionRequest) (*ModifyPositionResponse, error)
 	ClosePosition(context.Context, *ClosePositionRequest) (*ClosePositionResponse, error)
+	PlaceLimitOrder(context.Context, *PlaceLimitOrderRequest) (*PlaceLimitOrderResponse, error)
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
 	SetBalance(context.Context, *SetBalanceRequest) (*SetBalanceResponse, error)
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	SubscribePrices(*SubscribePricesRequest, Broker_SubscribePricesServer) error
+	StreamPositions(*StreamPositionsRequest, Broker_StreamPositionsServer) error
+	GetAccountHistory(context.Context, *GetAccountHistoryRequest) (*GetAccountHistoryResponse, error)
+	ListOpenPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error)
+	ListClosedPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error)
+	ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error)
+	GetQuote(context.Context, *GetQuoteRequest) (*Price, error)
+	GetMargin(context.Context, *GetMarginRequest) (*GetMarginResponse, error)
 	mustEmbedUnimplementedBrokerServer()
 }
 
@@ -114,15 +281,48 @@ func (UnimplementedBrokerServer) SignIn(context.Context, *SignInRequest) (*SignI
 func (UnimplementedBrokerServer) OpenPosition(context.Context, *OpenPositionRequest) (*OpenPositionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OpenPosition not implemented")
 }
+func (UnimplementedBrokerServer) ModifyPosition(context.Context, *ModifyPositionRequest) (*ModifyPositionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyPosition not implemented")
+}
 func (UnimplementedBrokerServer) ClosePosition(context.Context, *ClosePositionRequest) (*ClosePositionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClosePosition not implemented")
 }
+func (UnimplementedBrokerServer) PlaceLimitOrder(context.Context, *PlaceLimitOrderRequest) (*PlaceLimitOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceLimitOrder not implemented")
+}
+func (UnimplementedBrokerServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
 func (UnimplementedBrokerServer) SetBalance(context.Context, *SetBalanceRequest) (*SetBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetBalance not implemented")
 }
 func (UnimplementedBrokerServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
 }
+func (UnimplementedBrokerServer) SubscribePrices(*SubscribePricesRequest, Broker_SubscribePricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePrices not implemented")
+}
+func (UnimplementedBrokerServer) StreamPositions(*StreamPositionsRequest, Broker_StreamPositionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPositions not implemented")
+}
+func (UnimplementedBrokerServer) GetAccountHistory(context.Context, *GetAccountHistoryRequest) (*GetAccountHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountHistory not implemented")
+}
+func (UnimplementedBrokerServer) ListOpenPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOpenPositions not implemented")
+}
+func (UnimplementedBrokerServer) ListClosedPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClosedPositions not implemented")
+}
+func (UnimplementedBrokerServer) ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSymbols not implemented")
+}
+func (UnimplementedBrokerServer) GetQuote(context.Context, *GetQuoteRequest) (*Price, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedBrokerServer) GetMargin(context.Context, *GetMarginRequest) (*GetMarginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMargin not implemented")
+}
 func (UnimplementedBrokerServer) mustEmbedUnimplementedBrokerServer() {}
 
 // UnsafeBrokerServer may be embedded to opt out of forward compatibility for this service.
@@ -190,6 +390,24 @@ func _Broker_OpenPosition_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_ModifyPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyPositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ModifyPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/ModifyPosition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ModifyPosition(ctx, req.(*ModifyPositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Broker_ClosePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClosePositionRequest)
 	if err := dec(in); err != nil {
@@ -208,6 +426,42 @@ func _Broker_ClosePosition_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_PlaceLimitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceLimitOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).PlaceLimitOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/PlaceLimitOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).PlaceLimitOrder(ctx, req.(*PlaceLimitOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/PlaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Broker_SetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetBalanceRequest)
 	if err := dec(in); err != nil {
@@ -244,6 +498,156 @@ func _Broker_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_SubscribePrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribePricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BrokerServer).SubscribePrices(m, &brokerSubscribePricesServer{stream})
+}
+
+type Broker_SubscribePricesServer interface {
+	Send(*Price) error
+	grpc.ServerStream
+}
+
+type brokerSubscribePricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *brokerSubscribePricesServer) Send(m *Price) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Broker_StreamPositions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPositionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BrokerServer).StreamPositions(m, &brokerStreamPositionsServer{stream})
+}
+
+type Broker_StreamPositionsServer interface {
+	Send(*PositionEvent) error
+	grpc.ServerStream
+}
+
+type brokerStreamPositionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *brokerStreamPositionsServer) Send(m *PositionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Broker_GetAccountHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetAccountHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/GetAccountHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetAccountHistory(ctx, req.(*GetAccountHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_ListOpenPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ListOpenPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/ListOpenPositions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ListOpenPositions(ctx, req.(*ListPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_ListClosedPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ListClosedPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/ListClosedPositions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ListClosedPositions(ctx, req.(*ListPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_ListSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ListSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/ListSymbols",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ListSymbols(ctx, req.(*ListSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/GetQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_GetMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetMargin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Broker/GetMargin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetMargin(ctx, req.(*GetMarginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Broker_ServiceDesc is the grpc.ServiceDesc for Broker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -263,10 +667,22 @@ var Broker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OpenPosition",
 			Handler:    _Broker_OpenPosition_Handler,
 		},
+		{
+			MethodName: "ModifyPosition",
+			Handler:    _Broker_ModifyPosition_Handler,
+		},
 		{
 			MethodName: "ClosePosition",
 			Handler:    _Broker_ClosePosition_Handler,
 		},
+		{
+			MethodName: "PlaceLimitOrder",
+			Handler:    _Broker_PlaceLimitOrder_Handler,
+		},
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _Broker_PlaceOrder_Handler,
+		},
 		{
 			MethodName: "SetBalance",
 			Handler:    _Broker_SetBalance_Handler,
@@ -275,6 +691,271 @@ var Broker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBalance",
 			Handler:    _Broker_GetBalance_Handler,
 		},
+		{
+			MethodName: "GetAccountHistory",
+			Handler:    _Broker_GetAccountHistory_Handler,
+		},
+		{
+			MethodName: "ListOpenPositions",
+			Handler:    _Broker_ListOpenPositions_Handler,
+		},
+		{
+			MethodName: "ListClosedPositions",
+			Handler:    _Broker_ListClosedPositions_Handler,
+		},
+		{
+			MethodName: "ListSymbols",
+			Handler:    _Broker_ListSymbols_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _Broker_GetQuote_Handler,
+		},
+		{
+			MethodName: "GetMargin",
+			Handler:    _Broker_GetMargin_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePrices",
+			Handler:       _Broker_SubscribePrices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPositions",
+			Handler:       _Broker_StreamPositions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protocol/broker.proto",
+}
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	AddSymbol(ctx context.Context, in *AddSymbolRequest, opts ...grpc.CallOption) (*AddSymbolResponse, error)
+	DisableSymbol(ctx context.Context, in *DisableSymbolRequest, opts ...grpc.CallOption) (*DisableSymbolResponse, error)
+	SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*SetLeverageResponse, error)
+	GetEngineStats(ctx context.Context, in *GetEngineStatsRequest, opts ...grpc.CallOption) (*GetEngineStatsResponse, error)
+	GetPricerStatus(ctx context.Context, in *GetPricerStatusRequest, opts ...grpc.CallOption) (*GetPricerStatusResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) AddSymbol(ctx context.Context, in *AddSymbolRequest, opts ...grpc.CallOption) (*AddSymbolResponse, error) {
+	out := new(AddSymbolResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Admin/AddSymbol", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DisableSymbol(ctx context.Context, in *DisableSymbolRequest, opts ...grpc.CallOption) (*DisableSymbolResponse, error) {
+	out := new(DisableSymbolResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Admin/DisableSymbol", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*SetLeverageResponse, error) {
+	out := new(SetLeverageResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Admin/SetLeverage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetEngineStats(ctx context.Context, in *GetEngineStatsRequest, opts ...grpc.CallOption) (*GetEngineStatsResponse, error) {
+	out := new(GetEngineStatsResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Admin/GetEngineStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetPricerStatus(ctx context.Context, in *GetPricerStatusRequest, opts ...grpc.CallOption) (*GetPricerStatusResponse, error) {
+	out := new(GetPricerStatusResponse)
+	err := c.cc.Invoke(ctx, "/pgrpc.Admin/GetPricerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	AddSymbol(context.Context, *AddSymbolRequest) (*AddSymbolResponse, error)
+	DisableSymbol(context.Context, *DisableSymbolRequest) (*DisableSymbolResponse, error)
+	SetLeverage(context.Context, *SetLeverageRequest) (*SetLeverageResponse, error)
+	GetEngineStats(context.Context, *GetEngineStatsRequest) (*GetEngineStatsResponse, error)
+	GetPricerStatus(context.Context, *GetPricerStatusRequest) (*GetPricerStatusResponse, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) AddSymbol(context.Context, *AddSymbolRequest) (*AddSymbolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSymbol not implemented")
+}
+func (UnimplementedAdminServer) DisableSymbol(context.Context, *DisableSymbolRequest) (*DisableSymbolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableSymbol not implemented")
+}
+func (UnimplementedAdminServer) SetLeverage(context.Context, *SetLeverageRequest) (*SetLeverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLeverage not implemented")
+}
+func (UnimplementedAdminServer) GetEngineStats(context.Context, *GetEngineStatsRequest) (*GetEngineStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngineStats not implemented")
+}
+func (UnimplementedAdminServer) GetPricerStatus(context.Context, *GetPricerStatusRequest) (*GetPricerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPricerStatus not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_AddSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Admin/AddSymbol",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddSymbol(ctx, req.(*AddSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DisableSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DisableSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Admin/DisableSymbol",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DisableSymbol(ctx, req.(*DisableSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetLeverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetLeverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Admin/SetLeverage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetLeverage(ctx, req.(*SetLeverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetEngineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEngineStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetEngineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Admin/GetEngineStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetEngineStats(ctx, req.(*GetEngineStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetPricerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPricerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetPricerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pgrpc.Admin/GetPricerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetPricerStatus(ctx, req.(*GetPricerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pgrpc.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddSymbol",
+			Handler:    _Admin_AddSymbol_Handler,
+		},
+		{
+			MethodName: "DisableSymbol",
+			Handler:    _Admin_DisableSymbol_Handler,
+		},
+		{
+			MethodName: "SetLeverage",
+			Handler:    _Admin_SetLeverage_Handler,
+		},
+		{
+			MethodName: "GetEngineStats",
+			Handler:    _Admin_GetEngineStats_Handler,
+		},
+		{
+			MethodName: "GetPricerStatus",
+			Handler:    _Admin_GetPricerStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocol/broker.proto",